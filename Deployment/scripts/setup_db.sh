@@ -102,6 +102,15 @@ main() {
         "03_create_task_table.sql"
         "04_create_status_table.sql"
         "05_insert_dummy_data.sql"
+        "06_create_import_jobs_table.sql"
+        "07_add_task_natural_key.sql"
+        "08_create_import_ledger_table.sql"
+        "09_add_task_search_indexes.sql"
+        "10_create_clients_table.sql"
+        "11_create_token_tables.sql"
+        "12_add_client_scopes.sql"
+        "13_add_import_job_rejected_rows.sql"
+        "14_add_refresh_token_access_tokens.sql"
     )
 
     log_message "info" "Checking SQL files..."
@@ -133,6 +142,33 @@ main() {
 
         # Insert dummy data
         execute_sql_file "$SQL_DIR/05_insert_dummy_data.sql" "$DB_NAME" "Inserting dummy data..."
+
+        # Create import jobs table
+        execute_sql_file "$SQL_DIR/06_create_import_jobs_table.sql" "$DB_NAME" "Creating import jobs table..."
+
+        # Add task natural key
+        execute_sql_file "$SQL_DIR/07_add_task_natural_key.sql" "$DB_NAME" "Adding task natural key..."
+
+        # Create import ledger table
+        execute_sql_file "$SQL_DIR/08_create_import_ledger_table.sql" "$DB_NAME" "Creating import ledger table..."
+
+        # Add task search indexes
+        execute_sql_file "$SQL_DIR/09_add_task_search_indexes.sql" "$DB_NAME" "Adding task search indexes..."
+
+        # Create clients table
+        execute_sql_file "$SQL_DIR/10_create_clients_table.sql" "$DB_NAME" "Creating clients table..."
+
+        # Create token tables
+        execute_sql_file "$SQL_DIR/11_create_token_tables.sql" "$DB_NAME" "Creating token tables..."
+
+        # Add client scopes
+        execute_sql_file "$SQL_DIR/12_add_client_scopes.sql" "$DB_NAME" "Adding client scopes..."
+
+        # Add import job rejected rows
+        execute_sql_file "$SQL_DIR/13_add_import_job_rejected_rows.sql" "$DB_NAME" "Adding import job rejected rows..."
+
+        # Add refresh token access tokens
+        execute_sql_file "$SQL_DIR/14_add_refresh_token_access_tokens.sql" "$DB_NAME" "Adding refresh token access tokens..."
         
         log_message "info" "Database setup completed successfully!"
     else
@@ -0,0 +1,36 @@
+-- Create import jobs table
+CREATE TABLE IF NOT EXISTS task_management.import_jobs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_name VARCHAR(100) NOT NULL,
+    client_id UUID NOT NULL,
+    source TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'QUEUED',
+    rows_parsed INTEGER NOT NULL DEFAULT 0,
+    rows_validated INTEGER NOT NULL DEFAULT 0,
+    rows_inserted INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    started_at TIMESTAMP WITH TIME ZONE,
+    finished_at TIMESTAMP WITH TIME ZONE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    -- Check constraint for job status values
+    CONSTRAINT chk_valid_import_job_status
+        CHECK (status IN ('QUEUED', 'RUNNING', 'COMPLETED', 'FAILED', 'CANCELLED'))
+);
+
+COMMENT ON TABLE task_management.import_jobs IS 'Stores asynchronous task import jobs and their progress';
+COMMENT ON COLUMN task_management.import_jobs.client_id IS 'Client that submitted the job and owns the imported rows';
+COMMENT ON COLUMN task_management.import_jobs.source IS 'Import directory or uploaded file name';
+COMMENT ON COLUMN task_management.import_jobs.status IS 'Current job status';
+COMMENT ON COLUMN task_management.import_jobs.rows_parsed IS 'Number of rows parsed from the source';
+COMMENT ON COLUMN task_management.import_jobs.rows_validated IS 'Number of rows that passed validation';
+COMMENT ON COLUMN task_management.import_jobs.rows_inserted IS 'Number of rows inserted into tasks';
+COMMENT ON COLUMN task_management.import_jobs.error_message IS 'Reason the job failed, if any';
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_import_jobs_client
+ON task_management.import_jobs(client_id, created_at DESC);
+
+CREATE INDEX IF NOT EXISTS idx_import_jobs_status
+ON task_management.import_jobs(status);
\ No newline at end of file
@@ -0,0 +1,53 @@
+-- Active tasks are identified within a client by email, so repeated imports update
+-- rather than duplicate them. Earlier imports may have left several active tasks with
+-- the same email: keep the newest one active and deactivate the others. Nothing is
+-- deleted, so the deactivated tasks keep their fields and status history.
+DO $$
+DECLARE
+    deactivated INT;
+BEGIN
+    WITH ranked AS (
+        SELECT id,
+               ROW_NUMBER() OVER (
+                   PARTITION BY client_id, email
+                   ORDER BY created_at DESC NULLS LAST, id DESC
+               ) AS newest_first
+        FROM task_management.tasks
+        WHERE is_active
+    )
+    UPDATE task_management.tasks t
+    SET is_active = false,
+        updated_at = CURRENT_TIMESTAMP
+    FROM ranked r
+    WHERE t.id = r.id
+      AND r.newest_first > 1;
+
+    GET DIAGNOSTICS deactivated = ROW_COUNT;
+    IF deactivated > 0 THEN
+        RAISE WARNING 'Deactivated % duplicate tasks, keeping the newest active task for each client and email. Review them with: SELECT * FROM task_management.tasks WHERE NOT is_active AND (client_id, email) IN (SELECT client_id, email FROM task_management.tasks WHERE is_active)', deactivated;
+    END IF;
+END $$;
+
+-- Replace the index of earlier versions, which also covered deleted tasks and so kept
+-- their emails from being used again
+DO $$
+BEGIN
+    IF EXISTS (
+        SELECT 1
+        FROM pg_index i
+        JOIN pg_class c ON c.oid = i.indexrelid
+        JOIN pg_namespace n ON n.oid = c.relnamespace
+        WHERE n.nspname = 'task_management'
+          AND c.relname = 'uq_tasks_client_email'
+          AND i.indpred IS NULL
+    ) THEN
+        DROP INDEX task_management.uq_tasks_client_email;
+    END IF;
+END $$;
+
+-- Create natural key index; only active tasks hold their email
+CREATE UNIQUE INDEX IF NOT EXISTS uq_tasks_client_email
+ON task_management.tasks(client_id, email)
+WHERE is_active;
+
+COMMENT ON INDEX task_management.uq_tasks_client_email IS 'Natural key of active tasks, used by upsert imports';
@@ -0,0 +1,42 @@
+-- Create import ledger table
+CREATE TABLE IF NOT EXISTS task_management.import_ledger (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    client_name VARCHAR(100) NOT NULL,
+    client_id UUID NOT NULL,
+    file_name TEXT NOT NULL,
+    file_hash CHAR(64) NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    forced BOOLEAN NOT NULL DEFAULT FALSE,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    imported_rows INTEGER NOT NULL DEFAULT 0,
+    rejected_rows INTEGER NOT NULL DEFAULT 0,
+    inserted_rows INTEGER NOT NULL DEFAULT 0,
+    updated_rows INTEGER NOT NULL DEFAULT 0,
+    unchanged_rows INTEGER NOT NULL DEFAULT 0,
+    error_message TEXT,
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    finished_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    -- Check constraint for ledger outcomes
+    CONSTRAINT chk_valid_import_ledger_status
+        CHECK (status IN ('SUCCEEDED', 'FAILED', 'DUPLICATE'))
+);
+
+COMMENT ON TABLE task_management.import_ledger IS 'Records every imported file by content hash';
+COMMENT ON COLUMN task_management.import_ledger.client_id IS 'Client that imported the file';
+COMMENT ON COLUMN task_management.import_ledger.file_name IS 'Name of the imported file';
+COMMENT ON COLUMN task_management.import_ledger.file_hash IS 'Hex SHA-256 of the file content';
+COMMENT ON COLUMN task_management.import_ledger.status IS 'Outcome of the import';
+COMMENT ON COLUMN task_management.import_ledger.forced IS 'Whether the import was forced past an earlier successful import';
+COMMENT ON COLUMN task_management.import_ledger.imported_rows IS 'Number of rows written to tasks';
+COMMENT ON COLUMN task_management.import_ledger.rejected_rows IS 'Number of rows rejected';
+COMMENT ON COLUMN task_management.import_ledger.error_message IS 'Reason the import failed, if any';
+
+-- Create indexes
+CREATE INDEX IF NOT EXISTS idx_import_ledger_client_hash
+ON task_management.import_ledger(client_id, file_hash)
+WHERE status = 'SUCCEEDED';
+
+CREATE INDEX IF NOT EXISTS idx_import_ledger_client
+ON task_management.import_ledger(client_id, finished_at DESC);
@@ -0,0 +1,20 @@
+-- Task search matches words with full-text search and partial names, emails and
+-- addresses with trigrams
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- The expression must match the one used by the search query for the index to be used
+CREATE INDEX IF NOT EXISTS idx_tasks_search_vector
+ON task_management.tasks USING GIN (
+    to_tsvector('simple', COALESCE(name, '') || ' ' || COALESCE(email, '') || ' ' || COALESCE(address, ''))
+);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_name_trgm
+ON task_management.tasks USING GIN (name gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_email_trgm
+ON task_management.tasks USING GIN (email gin_trgm_ops);
+
+CREATE INDEX IF NOT EXISTS idx_tasks_address_trgm
+ON task_management.tasks USING GIN (address gin_trgm_ops);
+
+COMMENT ON INDEX task_management.idx_tasks_search_vector IS 'Full-text search over task name, email and address';
@@ -0,0 +1,21 @@
+-- Create clients table
+CREATE TABLE IF NOT EXISTS task_management.clients (
+    client_id UUID PRIMARY KEY,
+    client_name VARCHAR(100) NOT NULL,
+    secret_hash TEXT NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    secret_rotated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE task_management.clients IS 'API clients allowed to request tokens';
+COMMENT ON COLUMN task_management.clients.client_id IS 'Unique identifier of the client, used as client_id on its data';
+COMMENT ON COLUMN task_management.clients.client_name IS 'Name of the client, used as client_name on its data';
+COMMENT ON COLUMN task_management.clients.secret_hash IS 'bcrypt hash of the client API secret';
+COMMENT ON COLUMN task_management.clients.is_active IS 'Disabled clients cannot request tokens';
+COMMENT ON COLUMN task_management.clients.secret_rotated_at IS 'When the current secret was issued';
+
+-- Client names identify tenants together with their ID
+CREATE UNIQUE INDEX IF NOT EXISTS uq_clients_name
+ON task_management.clients(client_name);
@@ -0,0 +1,37 @@
+-- Create refresh tokens table
+CREATE TABLE IF NOT EXISTS task_management.refresh_tokens (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    family_id UUID NOT NULL,
+    client_name VARCHAR(100) NOT NULL,
+    client_id UUID NOT NULL,
+    token_hash CHAR(64) NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    revoked_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE task_management.refresh_tokens IS 'Refresh tokens issued to clients; each can be exchanged once';
+COMMENT ON COLUMN task_management.refresh_tokens.family_id IS 'First token of the rotation chain, shared by every token it was exchanged for';
+COMMENT ON COLUMN task_management.refresh_tokens.token_hash IS 'Hex SHA-256 of the token';
+COMMENT ON COLUMN task_management.refresh_tokens.used_at IS 'When the token was exchanged for a new one';
+COMMENT ON COLUMN task_management.refresh_tokens.revoked_at IS 'When the token was revoked';
+
+-- Create indexes
+CREATE UNIQUE INDEX IF NOT EXISTS uq_refresh_tokens_hash
+ON task_management.refresh_tokens(token_hash);
+
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_family
+ON task_management.refresh_tokens(family_id);
+
+-- Create revoked access tokens table
+CREATE TABLE IF NOT EXISTS task_management.revoked_tokens (
+    jti UUID PRIMARY KEY,
+    client_id UUID NOT NULL,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    revoked_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+COMMENT ON TABLE task_management.revoked_tokens IS 'Access tokens revoked before they expire';
+COMMENT ON COLUMN task_management.revoked_tokens.jti IS 'ID (jti claim) of the revoked token';
+COMMENT ON COLUMN task_management.revoked_tokens.expires_at IS 'When the token expires; the row is not needed afterwards';
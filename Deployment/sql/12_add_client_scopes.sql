@@ -0,0 +1,5 @@
+-- Add scopes to clients; existing clients keep access to tasks and imports
+ALTER TABLE task_management.clients
+ADD COLUMN IF NOT EXISTS scopes TEXT[] NOT NULL DEFAULT ARRAY['tasks:read', 'tasks:write', 'import:run'];
+
+COMMENT ON COLUMN task_management.clients.scopes IS 'Scopes granted to the tokens of the client: tasks:read, tasks:write, import:run, admin';
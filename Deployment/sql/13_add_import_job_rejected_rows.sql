@@ -0,0 +1,6 @@
+-- Add the rejected row count of partial imports; databases created before partial
+-- imports already have the import jobs table without it
+ALTER TABLE task_management.import_jobs
+ADD COLUMN IF NOT EXISTS rows_rejected INTEGER NOT NULL DEFAULT 0;
+
+COMMENT ON COLUMN task_management.import_jobs.rows_rejected IS 'Number of rows rejected by a partial import';
@@ -0,0 +1,15 @@
+-- Record the access token issued with each refresh token, so that revoking a refresh
+-- token family also revokes the access tokens minted from it
+ALTER TABLE task_management.refresh_tokens
+ADD COLUMN IF NOT EXISTS access_jti UUID,
+ADD COLUMN IF NOT EXISTS access_expires_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN task_management.refresh_tokens.access_jti IS 'ID (jti claim) of the access token issued with the refresh token';
+COMMENT ON COLUMN task_management.refresh_tokens.access_expires_at IS 'When the access token issued with the refresh token expires';
+
+-- Create indexes for purging expired rows
+CREATE INDEX IF NOT EXISTS idx_refresh_tokens_expires
+ON task_management.refresh_tokens(expires_at);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_tokens_expires
+ON task_management.revoked_tokens(expires_at);
@@ -0,0 +1,160 @@
+package CommandRepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/config"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/lib/pq"
+	"github.com/sirupsen/logrus"
+)
+
+const clientColumns = `
+	client_id, client_name, secret_hash, is_active, scopes, secret_rotated_at, created_at, updated_at`
+
+type clientRepository struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewClientRepository creates a new instance of ClientRepository
+func NewClientRepository(cfg *config.DatabaseConfig, logger *logrus.Logger) (interfaces.ClientRepository, error) {
+	db, err := sql.Open("postgres", cfg.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	// Set connection pool settings
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	// Verify database connection
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	logger.Info("Client repository initialized successfully")
+	return &clientRepository{
+		db:     db,
+		logger: logger,
+	}, nil
+}
+
+// scanClient reads a row selected with clientColumns
+func scanClient(row *sql.Row) (*schemas.ClientModel, error) {
+	var client schemas.ClientModel
+	err := row.Scan(
+		&client.ClientID,
+		&client.ClientName,
+		&client.SecretHash,
+		&client.IsActive,
+		pq.Array(&client.Scopes),
+		&client.SecretRotatedAt,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, interfaces.ErrClientNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+// CreateClient stores a new active client and fills in its timestamps
+func (r *clientRepository) CreateClient(ctx context.Context, client *schemas.ClientModel) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO task_management.clients (client_id, client_name, secret_hash, scopes)
+		VALUES ($1, $2, $3, $4)
+		RETURNING is_active, secret_rotated_at, created_at, updated_at
+	`, client.ClientID, client.ClientName, client.SecretHash, pq.Array(client.Scopes)).Scan(
+		&client.IsActive,
+		&client.SecretRotatedAt,
+		&client.CreatedAt,
+		&client.UpdatedAt,
+	)
+	if isUniqueViolation(err) {
+		return interfaces.ErrDuplicateClient
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to create client")
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	r.logger.WithField("client_id", client.ClientID).Info("Client created")
+	return nil
+}
+
+// GetClient retrieves a client, active or not
+func (r *clientRepository) GetClient(ctx context.Context, clientID string) (*schemas.ClientModel, error) {
+	client, err := scanClient(r.db.QueryRowContext(ctx, `
+		SELECT `+clientColumns+`
+		FROM task_management.clients
+		WHERE client_id = $1
+	`, clientID))
+	if err != nil && !errors.Is(err, interfaces.ErrClientNotFound) {
+		r.logger.WithError(err).Error("Failed to get client")
+		return nil, fmt.Errorf("failed to get client: %w", err)
+	}
+	return client, err
+}
+
+// UpdateClientSecret replaces the secret hash of a client and returns the client
+func (r *clientRepository) UpdateClientSecret(ctx context.Context, clientID string, secretHash string) (*schemas.ClientModel, error) {
+	client, err := scanClient(r.db.QueryRowContext(ctx, `
+		UPDATE task_management.clients
+		SET secret_hash = $2,
+			secret_rotated_at = CURRENT_TIMESTAMP,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE client_id = $1
+		RETURNING `+clientColumns,
+		clientID, secretHash,
+	))
+	if err != nil && !errors.Is(err, interfaces.ErrClientNotFound) {
+		r.logger.WithError(err).Error("Failed to update client secret")
+		return nil, fmt.Errorf("failed to update client secret: %w", err)
+	}
+	return client, err
+}
+
+// UpdateClientScopes replaces the scopes of a client and returns the client
+func (r *clientRepository) UpdateClientScopes(ctx context.Context, clientID string, scopes []string) (*schemas.ClientModel, error) {
+	client, err := scanClient(r.db.QueryRowContext(ctx, `
+		UPDATE task_management.clients
+		SET scopes = $2,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE client_id = $1
+		RETURNING `+clientColumns,
+		clientID, pq.Array(scopes),
+	))
+	if err != nil && !errors.Is(err, interfaces.ErrClientNotFound) {
+		r.logger.WithError(err).Error("Failed to update client scopes")
+		return nil, fmt.Errorf("failed to update client scopes: %w", err)
+	}
+	return client, err
+}
+
+// DisableClient deactivates a client and returns it
+func (r *clientRepository) DisableClient(ctx context.Context, clientID string) (*schemas.ClientModel, error) {
+	client, err := scanClient(r.db.QueryRowContext(ctx, `
+		UPDATE task_management.clients
+		SET is_active = false,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE client_id = $1
+		RETURNING `+clientColumns,
+		clientID,
+	))
+	if err != nil && !errors.Is(err, interfaces.ErrClientNotFound) {
+		r.logger.WithError(err).Error("Failed to disable client")
+		return nil, fmt.Errorf("failed to disable client: %w", err)
+	}
+	return client, err
+}
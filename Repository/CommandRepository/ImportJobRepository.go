@@ -0,0 +1,185 @@
+package CommandRepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/config"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/sirupsen/logrus"
+)
+
+type importJobRepository struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewImportJobRepository creates a new instance of ImportJobRepository
+func NewImportJobRepository(cfg *config.DatabaseConfig, logger *logrus.Logger) (interfaces.ImportJobRepository, error) {
+	db, err := sql.Open("postgres", cfg.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	// Set connection pool settings
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	// Verify database connection
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	logger.Info("Import job repository initialized successfully")
+	return &importJobRepository{
+		db:     db,
+		logger: logger,
+	}, nil
+}
+
+// CreateImportJob stores a new queued job and fills in its ID and creation time
+func (r *importJobRepository) CreateImportJob(ctx context.Context, job *schemas.ImportJobModel) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO task_management.import_jobs (client_name, client_id, source, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at
+	`, job.ClientName, job.ClientID, job.Source, schemas.ImportJobQueued).Scan(&job.ID, &job.CreatedAt)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to create import job")
+		return fmt.Errorf("failed to create import job: %w", err)
+	}
+
+	job.Status = schemas.ImportJobQueued
+	r.logger.WithField("job_id", job.ID).Info("Import job created")
+	return nil
+}
+
+// StartImportJob moves a queued job to running, reporting false if it was no longer queued
+func (r *importJobRepository) StartImportJob(ctx context.Context, jobID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE task_management.import_jobs
+		SET status = $2, started_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $3
+	`, jobID, schemas.ImportJobRunning, schemas.ImportJobQueued)
+	if err != nil {
+		return false, fmt.Errorf("failed to start import job: %w", err)
+	}
+
+	return rowsChanged(result)
+}
+
+// UpdateImportJobProgress records row counts for a running job
+func (r *importJobRepository) UpdateImportJobProgress(ctx context.Context, jobID string, progress schemas.ImportProgress) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE task_management.import_jobs
+		SET rows_parsed = $2, rows_validated = $3, rows_inserted = $4, rows_rejected = $5,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status = $6
+	`, jobID, progress.RowsParsed, progress.RowsValidated, progress.RowsInserted, progress.RowsRejected,
+		schemas.ImportJobRunning)
+	if err != nil {
+		return fmt.Errorf("failed to update import job progress: %w", err)
+	}
+
+	return nil
+}
+
+// FinishImportJob records the terminal status of a job that has not finished yet
+func (r *importJobRepository) FinishImportJob(ctx context.Context, jobID string, status string, errorMessage string) error {
+	_, err := r.db.ExecContext(ctx, `
+		UPDATE task_management.import_jobs
+		SET status = $2, error_message = NULLIF($3, ''),
+			finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND status IN ($4, $5)
+	`, jobID, status, errorMessage, schemas.ImportJobQueued, schemas.ImportJobRunning)
+	if err != nil {
+		return fmt.Errorf("failed to finish import job: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"job_id": jobID,
+		"status": status,
+	}).Info("Import job finished")
+	return nil
+}
+
+// CancelQueuedImportJob cancels a job that has not started, reporting false if it already had
+func (r *importJobRepository) CancelQueuedImportJob(ctx context.Context, jobID string, clientID string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE task_management.import_jobs
+		SET status = $3, finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND client_id = $2 AND status = $4
+	`, jobID, clientID, schemas.ImportJobCancelled, schemas.ImportJobQueued)
+	if err != nil {
+		return false, fmt.Errorf("failed to cancel import job: %w", err)
+	}
+
+	return rowsChanged(result)
+}
+
+// GetImportJob retrieves a job owned by the given client
+func (r *importJobRepository) GetImportJob(ctx context.Context, jobID string, clientID string) (*schemas.ImportJobModel, error) {
+	var job schemas.ImportJobModel
+	var errorMessage sql.NullString
+	err := r.db.QueryRowContext(ctx, `
+		SELECT
+			id, client_name, client_id, source, status,
+			rows_parsed, rows_validated, rows_inserted, rows_rejected, error_message,
+			created_at, started_at, finished_at
+		FROM task_management.import_jobs
+		WHERE id = $1 AND client_id = $2
+	`, jobID, clientID).Scan(
+		&job.ID,
+		&job.ClientName,
+		&job.ClientID,
+		&job.Source,
+		&job.Status,
+		&job.RowsParsed,
+		&job.RowsValidated,
+		&job.RowsInserted,
+		&job.RowsRejected,
+		&errorMessage,
+		&job.CreatedAt,
+		&job.StartedAt,
+		&job.FinishedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, interfaces.ErrImportJobNotFound
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query import job")
+		return nil, fmt.Errorf("failed to query import job: %w", err)
+	}
+
+	job.ErrorMessage = errorMessage.String
+	return &job, nil
+}
+
+// FailInterruptedImportJobs marks jobs left queued or running by a previous process as failed
+func (r *importJobRepository) FailInterruptedImportJobs(ctx context.Context) (int64, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE task_management.import_jobs
+		SET status = $1, error_message = 'Interrupted by server restart',
+			finished_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP
+		WHERE status IN ($2, $3)
+	`, schemas.ImportJobFailed, schemas.ImportJobQueued, schemas.ImportJobRunning)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fail interrupted import jobs: %w", err)
+	}
+
+	return result.RowsAffected()
+}
+
+func rowsChanged(result sql.Result) (bool, error) {
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to read affected rows: %w", err)
+	}
+	return affected > 0, nil
+}
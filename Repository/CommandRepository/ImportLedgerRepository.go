@@ -0,0 +1,180 @@
+package CommandRepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/config"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/sirupsen/logrus"
+)
+
+const ledgerColumns = `
+	id, client_name, client_id, file_name, file_hash, status, forced,
+	total_rows, imported_rows, rejected_rows, inserted_rows, updated_rows, unchanged_rows,
+	error_message, started_at, finished_at`
+
+type importLedgerRepository struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewImportLedgerRepository creates a new instance of ImportLedgerRepository
+func NewImportLedgerRepository(cfg *config.DatabaseConfig, logger *logrus.Logger) (interfaces.ImportLedgerRepository, error) {
+	db, err := sql.Open("postgres", cfg.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	// Set connection pool settings
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	// Verify database connection
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	logger.Info("Import ledger repository initialized successfully")
+	return &importLedgerRepository{
+		db:     db,
+		logger: logger,
+	}, nil
+}
+
+// RecordImport stores a finished import and fills in its ID
+func (r *importLedgerRepository) RecordImport(ctx context.Context, entry *schemas.ImportLedgerModel) error {
+	if err := insertLedgerEntry(ctx, r.db, entry); err != nil {
+		r.logger.WithError(err).Error("Failed to record import")
+		return err
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"ledger_id": entry.ID,
+		"file":      entry.FileName,
+		"status":    entry.Status,
+	}).Info("Import recorded in ledger")
+	return nil
+}
+
+// ListImports returns the most recent imports of the client, newest first
+func (r *importLedgerRepository) ListImports(ctx context.Context, clientID string, limit int) ([]schemas.ImportLedgerModel, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT`+ledgerColumns+`
+		FROM task_management.import_ledger
+		WHERE client_id = $1
+		ORDER BY finished_at DESC
+		LIMIT $2
+	`, clientID, limit)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query import ledger")
+		return nil, fmt.Errorf("failed to query import ledger: %w", err)
+	}
+	defer rows.Close()
+
+	entries := []schemas.ImportLedgerModel{}
+	for rows.Next() {
+		entry, err := scanLedgerEntry(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import ledger entry: %w", err)
+		}
+		entries = append(entries, *entry)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating import ledger: %w", err)
+	}
+
+	return entries, nil
+}
+
+// scanLedgerEntry reads a row selected with ledgerColumns
+func scanLedgerEntry(row interface{ Scan(dest ...any) error }) (*schemas.ImportLedgerModel, error) {
+	var entry schemas.ImportLedgerModel
+	var errorMessage sql.NullString
+	err := row.Scan(
+		&entry.ID,
+		&entry.ClientName,
+		&entry.ClientID,
+		&entry.FileName,
+		&entry.FileHash,
+		&entry.Status,
+		&entry.Forced,
+		&entry.TotalRows,
+		&entry.ImportedRows,
+		&entry.RejectedRows,
+		&entry.Inserted,
+		&entry.Updated,
+		&entry.Unchanged,
+		&errorMessage,
+		&entry.StartedAt,
+		&entry.FinishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	entry.ErrorMessage = errorMessage.String
+	return &entry, nil
+}
+
+// insertLedgerEntry stores a finished import on the database or inside a transaction and
+// fills in its ID
+func insertLedgerEntry(ctx context.Context, q rowQuerier, entry *schemas.ImportLedgerModel) error {
+	err := q.QueryRowContext(ctx, `
+		INSERT INTO task_management.import_ledger (
+			client_name, client_id, file_name, file_hash, status, forced,
+			total_rows, imported_rows, rejected_rows, inserted_rows, updated_rows, unchanged_rows,
+			error_message, started_at, finished_at
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, NULLIF($13, ''), $14, $15)
+		RETURNING id
+	`,
+		entry.ClientName,
+		entry.ClientID,
+		entry.FileName,
+		entry.FileHash,
+		entry.Status,
+		entry.Forced,
+		entry.TotalRows,
+		entry.ImportedRows,
+		entry.RejectedRows,
+		entry.Inserted,
+		entry.Updated,
+		entry.Unchanged,
+		entry.ErrorMessage,
+		entry.StartedAt,
+		entry.FinishedAt,
+	).Scan(&entry.ID)
+	if err != nil {
+		return fmt.Errorf("failed to record import: %w", err)
+	}
+	return nil
+}
+
+// findSuccessfulImport returns the latest successful import of a file with the given hash
+// by the client, or ErrImportLedgerEntryNotFound
+func findSuccessfulImport(ctx context.Context, q rowQuerier, clientID string, fileHash string) (*schemas.ImportLedgerModel, error) {
+	row := q.QueryRowContext(ctx, `
+		SELECT`+ledgerColumns+`
+		FROM task_management.import_ledger
+		WHERE client_id = $1 AND file_hash = $2 AND status = $3
+		ORDER BY finished_at DESC
+		LIMIT 1
+	`, clientID, fileHash, schemas.ImportLedgerSucceeded)
+
+	entry, err := scanLedgerEntry(row)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, interfaces.ErrImportLedgerEntryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query import ledger: %w", err)
+	}
+	return entry, nil
+}
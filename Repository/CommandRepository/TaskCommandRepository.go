@@ -1,15 +1,18 @@
 package CommandRepository
 
 import (
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
 	"time"
 
 	"taskmanager/Repository/CommandRepository/interfaces"
 	"taskmanager/RequestControllers/httpSetup/config"
 	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	taskSchemas "taskmanager/Services/CommandServices/TaskCommandService/schemas"
 
-	_ "github.com/lib/pq"
+	"github.com/lib/pq"
 	"github.com/sirupsen/logrus"
 )
 
@@ -43,31 +46,142 @@ func NewTaskCommandRepository(cfg *config.DatabaseConfig, logger *logrus.Logger)
 	return repo, nil
 }
 
-// BulkCreateTasks handles bulk insertion of tasks
-func (r *taskCommandRepository) BulkCreateTasks(tasks []schemas.TaskModel) error {
-	r.logger.WithField("task_count", len(tasks)).Info("Starting bulk task creation")
+// taskColumns are the columns written by an import, in COPY order
+var taskColumns = []string{
+	"name", "email", "age", "address", "phone_number",
+	"department", "position", "salary", "hire_date",
+	"is_active", "client_name", "client_id",
+}
+
+// stagingTable receives each upsert batch before it is merged into tasks
+const stagingTable = "task_import_staging"
+
+const createStagingTableQuery = `
+	CREATE TEMP TABLE task_import_staging ON COMMIT DROP AS
+	SELECT name, email, age, address, phone_number,
+	       department, position, salary, hire_date,
+	       is_active, client_name, client_id
+	FROM task_management.tasks
+	WITH NO DATA`
+
+// mergeStagingQuery upserts the staged tasks on (client_id, email) of the active tasks.
+// Deleted tasks are not matched, so an import of their email creates a new task. Rows
+// whose fields already match are skipped by the WHERE clause and so are not returned;
+// xmax is zero only for freshly inserted rows. is_active is left alone on update since
+// it is not part of the imported data.
+const mergeStagingQuery = `
+	WITH merged AS (
+		INSERT INTO task_management.tasks AS t
+			(name, email, age, address, phone_number,
+			 department, position, salary, hire_date,
+			 is_active, client_name, client_id)
+		SELECT name, email, age, address, phone_number,
+		       department, position, salary, hire_date,
+		       is_active, client_name, client_id
+		FROM task_import_staging
+		ON CONFLICT (client_id, email) WHERE is_active DO UPDATE SET
+			name = EXCLUDED.name,
+			age = EXCLUDED.age,
+			address = EXCLUDED.address,
+			phone_number = EXCLUDED.phone_number,
+			department = EXCLUDED.department,
+			position = EXCLUDED.position,
+			salary = EXCLUDED.salary,
+			hire_date = EXCLUDED.hire_date,
+			client_name = EXCLUDED.client_name,
+			updated_at = CURRENT_TIMESTAMP
+		WHERE (t.name, t.age, t.address, t.phone_number, t.department,
+		       t.position, t.salary, t.hire_date, t.client_name)
+		IS DISTINCT FROM (EXCLUDED.name, EXCLUDED.age, EXCLUDED.address, EXCLUDED.phone_number, EXCLUDED.department,
+		                  EXCLUDED.position, EXCLUDED.salary, EXCLUDED.hire_date, EXCLUDED.client_name)
+		RETURNING (xmax = 0) AS inserted
+	)
+	SELECT
+		COUNT(*) FILTER (WHERE inserted),
+		COUNT(*) FILTER (WHERE NOT inserted)
+	FROM merged`
+
+// uniqueViolation is the PostgreSQL error code for a unique constraint violation
+const uniqueViolation = "23505"
+
+type taskBatchWriter struct {
+	tx      *sql.Tx
+	logger  *logrus.Logger
+	upsert  bool
+	staged  bool
+	written int
+}
+
+// BeginTaskImport opens a transaction that stores an import in batches
+func (r *taskCommandRepository) BeginTaskImport(ctx context.Context) (interfaces.TaskBatchWriter, error) {
+	return r.beginTaskImport(ctx, false)
+}
+
+// BeginTaskUpsert opens a transaction that merges an import into the existing tasks of
+// its client, keyed on email
+func (r *taskCommandRepository) BeginTaskUpsert(ctx context.Context) (interfaces.TaskBatchWriter, error) {
+	return r.beginTaskImport(ctx, true)
+}
 
-	tx, err := r.db.Begin()
+func (r *taskCommandRepository) beginTaskImport(ctx context.Context, upsert bool) (interfaces.TaskBatchWriter, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
 	if err != nil {
 		r.logger.WithError(err).Error("Failed to begin transaction")
-		return fmt.Errorf("failed to begin transaction: %w", err)
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
-	defer tx.Rollback()
 
-	stmt, err := tx.Prepare(`
-		INSERT INTO task_management.tasks (
-			name, email, age, address, phone_number, 
-			department, position, salary, hire_date, 
-			is_active, client_name, client_id
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
-	`)
+	r.logger.WithField("upsert", upsert).Info("Started task import transaction")
+	return &taskBatchWriter{
+		tx:     tx,
+		logger: r.logger,
+		upsert: upsert,
+	}, nil
+}
+
+// WriteBatch stores a batch of tasks, copying them straight into the tasks table or,
+// for an upsert, merging them through the staging table
+func (w *taskBatchWriter) WriteBatch(ctx context.Context, tasks []schemas.TaskModel) (schemas.ImportWriteCounts, error) {
+	for i, task := range tasks {
+		if task.ClientID == "" {
+			return schemas.ImportWriteCounts{}, fmt.Errorf("task at row %d has no client assigned", w.written+i+1)
+		}
+	}
+
+	var counts schemas.ImportWriteCounts
+	if w.upsert {
+		var err error
+		if counts, err = w.upsertTasks(ctx, tasks); err != nil {
+			return schemas.ImportWriteCounts{}, err
+		}
+	} else {
+		if err := w.copyTasks(ctx, pq.CopyInSchema("task_management", "tasks", taskColumns...), tasks); err != nil {
+			return schemas.ImportWriteCounts{}, err
+		}
+		counts.Inserted = len(tasks)
+	}
+
+	w.written += len(tasks)
+	w.logger.WithFields(logrus.Fields{
+		"batch_size":    len(tasks),
+		"inserted":      counts.Inserted,
+		"updated":       counts.Updated,
+		"unchanged":     counts.Unchanged,
+		"written_total": w.written,
+	}).Debug("Wrote task batch")
+	return counts, nil
+}
+
+// copyTasks streams tasks into the table named by a COPY statement
+func (w *taskBatchWriter) copyTasks(ctx context.Context, copyQuery string, tasks []schemas.TaskModel) error {
+	stmt, err := w.tx.PrepareContext(ctx, copyQuery)
 	if err != nil {
-		return fmt.Errorf("failed to prepare statement: %w", err)
+		return fmt.Errorf("failed to prepare copy: %w", err)
 	}
 	defer stmt.Close()
 
-	for i, task := range tasks {
-		_, err = stmt.Exec(
+	for _, task := range tasks {
+		_, err = stmt.ExecContext(
+			ctx,
 			task.Name,
 			task.Email,
 			task.Age,
@@ -82,8 +196,412 @@ func (r *taskCommandRepository) BulkCreateTasks(tasks []schemas.TaskModel) error
 			task.ClientID,
 		)
 		if err != nil {
-			return fmt.Errorf("failed to insert task at row %d: %w", i+1, err)
+			return copyError(err)
+		}
+	}
+
+	// An empty Exec flushes the buffered rows to the server
+	if _, err := stmt.ExecContext(ctx); err != nil {
+		return copyError(err)
+	}
+	return nil
+}
+
+// upsertTasks merges a batch into tasks. A key may only be merged once per statement,
+// so a batch that repeats a client and email is merged in rounds, applying the
+// repeats in file order.
+func (w *taskBatchWriter) upsertTasks(ctx context.Context, tasks []schemas.TaskModel) (schemas.ImportWriteCounts, error) {
+	var counts schemas.ImportWriteCounts
+
+	if !w.staged {
+		if _, err := w.tx.ExecContext(ctx, createStagingTableQuery); err != nil {
+			return counts, fmt.Errorf("failed to create staging table: %w", err)
+		}
+		w.staged = true
+	}
+
+	for pending := tasks; len(pending) > 0; {
+		var round []schemas.TaskModel
+		round, pending = splitRepeatedKeys(pending)
+
+		if _, err := w.tx.ExecContext(ctx, "TRUNCATE "+stagingTable); err != nil {
+			return counts, fmt.Errorf("failed to clear staging table: %w", err)
 		}
+		if err := w.copyTasks(ctx, pq.CopyIn(stagingTable, taskColumns...), round); err != nil {
+			return counts, err
+		}
+
+		var inserted, updated int
+		if err := w.tx.QueryRowContext(ctx, mergeStagingQuery).Scan(&inserted, &updated); err != nil {
+			return counts, fmt.Errorf("failed to merge task batch: %w", err)
+		}
+
+		counts.Inserted += inserted
+		counts.Updated += updated
+		counts.Unchanged += len(round) - inserted - updated
+	}
+
+	return counts, nil
+}
+
+// splitRepeatedKeys returns the first task for each client and email, in order, and
+// the tasks that repeat an earlier key
+func splitRepeatedKeys(tasks []schemas.TaskModel) ([]schemas.TaskModel, []schemas.TaskModel) {
+	type taskKey struct{ clientID, email string }
+
+	seen := make(map[taskKey]bool, len(tasks))
+	var unique, repeated []schemas.TaskModel
+	for _, task := range tasks {
+		key := taskKey{task.ClientID, task.Email}
+		if seen[key] {
+			repeated = append(repeated, task)
+			continue
+		}
+		seen[key] = true
+		unique = append(unique, task)
+	}
+	return unique, repeated
+}
+
+// copyError explains a failed COPY, pointing duplicate tasks at upsert
+func copyError(err error) error {
+	if isUniqueViolation(err) {
+		return fmt.Errorf("a task with the same client and email already exists, import with upsert to update it: %w", err)
+	}
+	return fmt.Errorf("failed to copy task batch: %w", err)
+}
+
+// isUniqueViolation reports whether a statement broke a unique index
+func isUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+	return errors.As(err, &pqErr) && pqErr.Code == uniqueViolation
+}
+
+// LockFile takes a transaction-scoped advisory lock keyed on the client and file hash
+// before looking the file up in the import ledger, so a concurrent import of the same
+// content waits here until this one has committed its ledger entry or rolled back
+func (w *taskBatchWriter) LockFile(ctx context.Context, clientID string, fileHash string) (*schemas.ImportLedgerModel, error) {
+	_, err := w.tx.ExecContext(ctx, `
+		SELECT pg_advisory_xact_lock(hashtext($1), hashtext($2))
+	`, clientID, fileHash)
+	if err != nil {
+		w.logger.WithError(err).Error("Failed to lock import file")
+		return nil, fmt.Errorf("failed to lock import file: %w", err)
+	}
+
+	entry, err := findSuccessfulImport(ctx, w.tx, clientID, fileHash)
+	if err != nil && !errors.Is(err, interfaces.ErrImportLedgerEntryNotFound) {
+		w.logger.WithError(err).Error("Failed to query import ledger")
+	}
+	return entry, err
+}
+
+// RecordImport adds the ledger entry of the import to the transaction
+func (w *taskBatchWriter) RecordImport(ctx context.Context, entry *schemas.ImportLedgerModel) error {
+	if err := insertLedgerEntry(ctx, w.tx, entry); err != nil {
+		w.logger.WithError(err).Error("Failed to record import")
+		return err
+	}
+	return nil
+}
+
+func (w *taskBatchWriter) Commit() error {
+	if err := w.tx.Commit(); err != nil {
+		w.logger.WithError(err).Error("Failed to commit transaction")
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	w.logger.WithField("task_count", w.written).Info("Task import committed successfully")
+	return nil
+}
+
+func (w *taskBatchWriter) Rollback() error {
+	if err := w.tx.Rollback(); err != nil && !errors.Is(err, sql.ErrTxDone) {
+		return fmt.Errorf("failed to roll back transaction: %w", err)
+	}
+	return nil
+}
+
+// CreateTask stores a single task and fills in its ID and timestamps
+func (r *taskCommandRepository) CreateTask(ctx context.Context, task *schemas.TaskModel) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO task_management.tasks (
+			name, email, age, address, phone_number,
+			department, position, salary, hire_date,
+			is_active, client_name, client_id
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, created_at, updated_at
+	`,
+		task.Name,
+		task.Email,
+		task.Age,
+		task.Address,
+		task.PhoneNumber,
+		task.Department,
+		task.Position,
+		task.Salary,
+		task.HireDate,
+		task.IsActive,
+		task.ClientName,
+		task.ClientID,
+	).Scan(&task.ID, &task.CreatedAt, &task.UpdatedAt)
+	if isUniqueViolation(err) {
+		return interfaces.ErrDuplicateTask
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to create task")
+		return fmt.Errorf("failed to create task: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id":   task.ID,
+		"client_id": task.ClientID,
+	}).Info("Task created successfully")
+	return nil
+}
+
+// rowQuerier runs single-row queries on the database or inside a transaction
+type rowQuerier interface {
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// GetTask retrieves an active task owned by the given client
+func (r *taskCommandRepository) GetTask(ctx context.Context, clientID string, id int) (*schemas.TaskModel, error) {
+	return r.getTask(ctx, r.db, clientID, id, false)
+}
+
+// getTask retrieves an active task owned by the given client, locking its row until the
+// transaction ends when forUpdate is set
+func (r *taskCommandRepository) getTask(ctx context.Context, q rowQuerier, clientID string, id int, forUpdate bool) (*schemas.TaskModel, error) {
+	query := `
+		SELECT id, name, email, age, address, phone_number,
+		       department, position, salary, hire_date,
+		       is_active, client_name, client_id, created_at, updated_at
+		FROM task_management.tasks
+		WHERE id = $1 AND client_id = $2 AND is_active`
+	if forUpdate {
+		query += `
+		FOR UPDATE`
+	}
+
+	var task schemas.TaskModel
+	err := q.QueryRowContext(ctx, query, id, clientID).Scan(
+		&task.ID,
+		&task.Name,
+		&task.Email,
+		&task.Age,
+		&task.Address,
+		&task.PhoneNumber,
+		&task.Department,
+		&task.Position,
+		&task.Salary,
+		&task.HireDate,
+		&task.IsActive,
+		&task.ClientName,
+		&task.ClientID,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, interfaces.ErrTaskNotFound
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query task")
+		return nil, fmt.Errorf("failed to query task: %w", err)
+	}
+
+	return &task, nil
+}
+
+// UpdateTask replaces the fields of an active task owned by the task's client
+func (r *taskCommandRepository) UpdateTask(ctx context.Context, task *schemas.TaskModel) error {
+	return r.updateTask(ctx, r.db, task)
+}
+
+// updateTask replaces the fields of an active task through q
+func (r *taskCommandRepository) updateTask(ctx context.Context, q rowQuerier, task *schemas.TaskModel) error {
+	err := q.QueryRowContext(ctx, `
+		UPDATE task_management.tasks
+		SET name = $3, email = $4, age = $5, address = $6, phone_number = $7,
+		    department = $8, position = $9, salary = $10, hire_date = $11,
+		    client_name = $12, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND client_id = $2 AND is_active
+		RETURNING is_active, created_at, updated_at
+	`,
+		task.ID,
+		task.ClientID,
+		task.Name,
+		task.Email,
+		task.Age,
+		task.Address,
+		task.PhoneNumber,
+		task.Department,
+		task.Position,
+		task.Salary,
+		task.HireDate,
+		task.ClientName,
+	).Scan(&task.IsActive, &task.CreatedAt, &task.UpdatedAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return interfaces.ErrTaskNotFound
+	}
+	if isUniqueViolation(err) {
+		return interfaces.ErrDuplicateTask
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to update task")
+		return fmt.Errorf("failed to update task: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id":   task.ID,
+		"client_id": task.ClientID,
+	}).Info("Task updated successfully")
+	return nil
+}
+
+// PatchTask replaces the fields of an active task with those patch derives from them. The
+// task row is locked so that concurrent patches are applied one at a time and each one
+// starts from the fields the previous one wrote.
+func (r *taskCommandRepository) PatchTask(
+	ctx context.Context,
+	clientID string,
+	id int,
+	patch func(current schemas.TaskModel) (*schemas.TaskModel, error),
+) (*schemas.TaskModel, error) {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	current, err := r.getTask(ctx, tx, clientID, id, true)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := patch(*current)
+	if err != nil {
+		return nil, err
+	}
+	task.ID = current.ID
+	task.ClientID = current.ClientID
+	if err := r.updateTask(ctx, tx, task); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		r.logger.WithError(err).Error("Failed to commit transaction")
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return task, nil
+}
+
+// DeactivateTask soft deletes an active task owned by the given client
+func (r *taskCommandRepository) DeactivateTask(ctx context.Context, clientID string, id int) error {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE task_management.tasks
+		SET is_active = false, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND client_id = $2 AND is_active
+	`, id, clientID)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to deactivate task")
+		return fmt.Errorf("failed to deactivate task: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to deactivate task: %w", err)
+	}
+	if affected == 0 {
+		return interfaces.ErrTaskNotFound
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"task_id":   id,
+		"client_id": clientID,
+	}).Info("Task deactivated successfully")
+	return nil
+}
+
+// latestStatusQuery selects the most recent status of a task, if it has any
+const latestStatusQuery = `
+	SELECT status
+	FROM task_management.task_status
+	WHERE task_id = $1
+	ORDER BY created_at DESC, id DESC
+	LIMIT 1`
+
+// GetTaskStatus returns the latest status of an active task owned by the given client
+func (r *taskCommandRepository) GetTaskStatus(ctx context.Context, clientID string, taskID int) (string, error) {
+	var status sql.NullString
+	err := r.db.QueryRowContext(ctx, `
+		SELECT (`+latestStatusQuery+`)
+		FROM task_management.tasks
+		WHERE id = $1 AND client_id = $2 AND is_active
+	`, taskID, clientID).Scan(&status)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", interfaces.ErrTaskNotFound
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query task status")
+		return "", fmt.Errorf("failed to query task status: %w", err)
+	}
+
+	return status.String, nil
+}
+
+// AppendTaskStatus adds a status change to the history of an active task. The task row
+// is locked so that concurrent changes are applied one at a time and each one sees the
+// status the previous one wrote.
+func (r *taskCommandRepository) AppendTaskStatus(ctx context.Context, entry *taskSchemas.TaskStatusModel, expected string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRowContext(ctx, `
+		SELECT client_name
+		FROM task_management.tasks
+		WHERE id = $1 AND client_id = $2 AND is_active
+		FOR UPDATE
+	`, entry.TaskID, entry.ClientID).Scan(&entry.ClientName)
+	if errors.Is(err, sql.ErrNoRows) {
+		return interfaces.ErrTaskNotFound
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to lock task")
+		return fmt.Errorf("failed to lock task: %w", err)
+	}
+
+	var current string
+	err = tx.QueryRowContext(ctx, latestStatusQuery, entry.TaskID).Scan(&current)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		r.logger.WithError(err).Error("Failed to query task status")
+		return fmt.Errorf("failed to query task status: %w", err)
+	}
+	if current != expected {
+		return interfaces.ErrTaskStatusChanged
+	}
+
+	err = tx.QueryRowContext(ctx, `
+		INSERT INTO task_management.task_status
+			(task_id, client_name, client_id, status, status_description, updated_by)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6)
+		RETURNING id, created_at
+	`,
+		entry.TaskID,
+		entry.ClientName,
+		entry.ClientID,
+		entry.Status,
+		entry.StatusDescription,
+		entry.UpdatedBy,
+	).Scan(&entry.ID, &entry.CreatedAt)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to record task status")
+		return fmt.Errorf("failed to record task status: %w", err)
 	}
 
 	if err := tx.Commit(); err != nil {
@@ -91,6 +609,10 @@ func (r *taskCommandRepository) BulkCreateTasks(tasks []schemas.TaskModel) error
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	r.logger.WithField("task_count", len(tasks)).Info("Bulk task creation completed successfully")
+	r.logger.WithFields(logrus.Fields{
+		"task_id":   entry.TaskID,
+		"client_id": entry.ClientID,
+		"status":    entry.Status,
+	}).Info("Task status recorded successfully")
 	return nil
 }
@@ -0,0 +1,275 @@
+package CommandRepository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/config"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	_ "github.com/lib/pq"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	clientOneName = "Client One Corp"
+	clientOneUUID = "9ebcc92c-e186-41b3-834b-f75ab3f110ae"
+)
+
+func setupTestRepository(t *testing.T) *taskCommandRepository {
+	// Initialize logger
+	logger := logrus.New()
+	logger.SetLevel(logrus.DebugLevel)
+
+	// Load test configuration
+	cfg := &config.DatabaseConfig{
+		Host:     "localhost",
+		Port:     5432,
+		Username: "postgres",
+		Password: "peemak", // Use your actual test DB password
+		DBName:   "taskmanager",
+		SSLMode:  "disable",
+	}
+
+	// Initialize repository
+	repo, err := NewTaskCommandRepository(cfg, logger)
+	if err != nil {
+		t.Fatalf("Failed to create repository: %v", err)
+	}
+
+	return repo.(*taskCommandRepository)
+}
+
+// testTask returns a task of client one with an email no other test uses, and removes
+// every task with that email when the test ends
+func testTask(t *testing.T, db *sql.DB) schemas.TaskModel {
+	email := fmt.Sprintf("natural-key-%d@example.com", time.Now().UnixNano())
+	t.Cleanup(func() {
+		if _, err := db.Exec(`
+			DELETE FROM task_management.tasks WHERE client_id = $1 AND email = $2
+		`, clientOneUUID, email); err != nil {
+			t.Errorf("Failed to clean up tasks: %v", err)
+		}
+	})
+
+	return schemas.TaskModel{
+		Name:        "Jane Doe",
+		Email:       email,
+		Age:         30,
+		Address:     "123 Elm St",
+		PhoneNumber: "555-1234",
+		Department:  "Sales",
+		Position:    "Manager",
+		Salary:      60000,
+		HireDate:    time.Date(2020, 1, 15, 0, 0, 0, 0, time.UTC),
+		IsActive:    true,
+		ClientName:  clientOneName,
+		ClientID:    clientOneUUID,
+	}
+}
+
+// countTasks returns the number of active and inactive tasks of client one with email
+func countTasks(t *testing.T, db *sql.DB, email string) (active, inactive int) {
+	err := db.QueryRow(`
+		SELECT COUNT(*) FILTER (WHERE is_active), COUNT(*) FILTER (WHERE NOT is_active)
+		FROM task_management.tasks
+		WHERE client_id = $1 AND email = $2
+	`, clientOneUUID, email).Scan(&active, &inactive)
+	if err != nil {
+		t.Fatalf("Failed to count tasks: %v", err)
+	}
+	return active, inactive
+}
+
+// upsert imports tasks with upsert and returns the write counts
+func upsert(t *testing.T, repo *taskCommandRepository, tasks ...schemas.TaskModel) schemas.ImportWriteCounts {
+	ctx := context.Background()
+	writer, err := repo.BeginTaskUpsert(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin upsert: %v", err)
+	}
+	defer writer.Rollback()
+
+	counts, err := writer.WriteBatch(ctx, tasks)
+	if err != nil {
+		t.Fatalf("Failed to write batch: %v", err)
+	}
+	if err := writer.Commit(); err != nil {
+		t.Fatalf("Failed to commit upsert: %v", err)
+	}
+	return counts
+}
+
+func TestUpsertImportAfterDelete(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.db.Close()
+	ctx := context.Background()
+
+	task := testTask(t, repo.db)
+	created := task
+	assert.NoError(t, repo.CreateTask(ctx, &created))
+	assert.NoError(t, repo.DeactivateTask(ctx, clientOneUUID, created.ID))
+
+	// The deleted task does not match, so the import creates a new active task
+	task.Department = "Marketing"
+	counts := upsert(t, repo, task)
+	assert.Equal(t, schemas.ImportWriteCounts{Inserted: 1}, counts)
+
+	active, inactive := countTasks(t, repo.db, task.Email)
+	assert.Equal(t, 1, active)
+	assert.Equal(t, 1, inactive)
+	_, err := repo.GetTask(ctx, clientOneUUID, created.ID)
+	assert.Error(t, err, "deleted task must stay deleted")
+
+	// Importing again updates the new active task
+	task.Position = "Director"
+	counts = upsert(t, repo, task)
+	assert.Equal(t, schemas.ImportWriteCounts{Updated: 1}, counts)
+
+	active, inactive = countTasks(t, repo.db, task.Email)
+	assert.Equal(t, 1, active)
+	assert.Equal(t, 1, inactive)
+}
+
+func TestCreateTaskAfterDelete(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.db.Close()
+	ctx := context.Background()
+
+	task := testTask(t, repo.db)
+	first := task
+	assert.NoError(t, repo.CreateTask(ctx, &first))
+
+	// The email is taken while the task is active
+	duplicate := task
+	assert.ErrorIs(t, repo.CreateTask(ctx, &duplicate), interfaces.ErrDuplicateTask)
+
+	// Deleting the task frees its email
+	assert.NoError(t, repo.DeactivateTask(ctx, clientOneUUID, first.ID))
+	recreated := task
+	assert.NoError(t, repo.CreateTask(ctx, &recreated))
+	assert.NotEqual(t, first.ID, recreated.ID)
+
+	active, inactive := countTasks(t, repo.db, task.Email)
+	assert.Equal(t, 1, active)
+	assert.Equal(t, 1, inactive)
+}
+
+func TestUpdateTaskToDeletedEmail(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.db.Close()
+	ctx := context.Background()
+
+	deleted := testTask(t, repo.db)
+	assert.NoError(t, repo.CreateTask(ctx, &deleted))
+	assert.NoError(t, repo.DeactivateTask(ctx, clientOneUUID, deleted.ID))
+
+	other := testTask(t, repo.db)
+	assert.NoError(t, repo.CreateTask(ctx, &other))
+
+	// An email held only by a deleted task can be taken over
+	other.Email = deleted.Email
+	assert.NoError(t, repo.UpdateTask(ctx, &other))
+}
+
+func TestPatchTaskConcurrently(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.db.Close()
+	ctx := context.Background()
+
+	task := testTask(t, repo.db)
+	assert.NoError(t, repo.CreateTask(ctx, &task))
+
+	// Each patch changes a different field; both must survive
+	var wg sync.WaitGroup
+	errs := make([]error, 2)
+	patches := []func(task *schemas.TaskModel){
+		func(task *schemas.TaskModel) { task.Position = "Director" },
+		func(task *schemas.TaskModel) { task.Department = "Marketing" },
+	}
+	for i, change := range patches {
+		wg.Add(1)
+		go func(i int, change func(task *schemas.TaskModel)) {
+			defer wg.Done()
+			_, errs[i] = repo.PatchTask(ctx, clientOneUUID, task.ID, func(current schemas.TaskModel) (*schemas.TaskModel, error) {
+				// Give the other patch time to read the task if the row were not locked
+				time.Sleep(50 * time.Millisecond)
+				change(&current)
+				return &current, nil
+			})
+		}(i, change)
+	}
+	wg.Wait()
+
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	stored, err := repo.GetTask(ctx, clientOneUUID, task.ID)
+	if err != nil {
+		t.Fatalf("Failed to get task: %v", err)
+	}
+	assert.Equal(t, "Director", stored.Position)
+	assert.Equal(t, "Marketing", stored.Department)
+}
+
+func TestLockFileSerializesImports(t *testing.T) {
+	repo := setupTestRepository(t)
+	defer repo.db.Close()
+	ctx := context.Background()
+
+	fileHash := fmt.Sprintf("%064d", time.Now().UnixNano())
+	t.Cleanup(func() {
+		if _, err := repo.db.Exec(`
+			DELETE FROM task_management.import_ledger WHERE client_id = $1 AND file_hash = $2
+		`, clientOneUUID, fileHash); err != nil {
+			t.Errorf("Failed to clean up import ledger: %v", err)
+		}
+	})
+
+	first, err := repo.BeginTaskImport(ctx)
+	if err != nil {
+		t.Fatalf("Failed to begin import: %v", err)
+	}
+	defer first.Rollback()
+
+	_, err = first.LockFile(ctx, clientOneUUID, fileHash)
+	assert.ErrorIs(t, err, interfaces.ErrImportLedgerEntryNotFound)
+
+	// The second import of the same file waits for the first to commit, then sees it
+	found := make(chan *schemas.ImportLedgerModel, 1)
+	go func() {
+		second, err := repo.BeginTaskImport(ctx)
+		if err != nil {
+			found <- nil
+			return
+		}
+		defer second.Rollback()
+
+		previous, _ := second.LockFile(ctx, clientOneUUID, fileHash)
+		found <- previous
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	entry := &schemas.ImportLedgerModel{
+		ClientName: clientOneName,
+		ClientID:   clientOneUUID,
+		FileName:   "tasks.csv",
+		FileHash:   fileHash,
+		Status:     schemas.ImportLedgerSucceeded,
+		StartedAt:  time.Now(),
+		FinishedAt: time.Now(),
+	}
+	assert.NoError(t, first.RecordImport(ctx, entry))
+	assert.NoError(t, first.Commit())
+
+	previous := <-found
+	if assert.NotNil(t, previous) {
+		assert.Equal(t, entry.ID, previous.ID)
+	}
+}
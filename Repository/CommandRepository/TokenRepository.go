@@ -0,0 +1,210 @@
+package CommandRepository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/config"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/sirupsen/logrus"
+)
+
+type tokenRepository struct {
+	db     *sql.DB
+	logger *logrus.Logger
+}
+
+// NewTokenRepository creates a new instance of TokenRepository
+func NewTokenRepository(cfg *config.DatabaseConfig, logger *logrus.Logger) (interfaces.TokenRepository, error) {
+	db, err := sql.Open("postgres", cfg.ConnectionString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database connection: %w", err)
+	}
+
+	// Set connection pool settings
+	db.SetMaxOpenConns(10)
+	db.SetMaxIdleConns(10)
+	db.SetConnMaxLifetime(5 * time.Minute)
+
+	// Verify database connection
+	if err := db.Ping(); err != nil {
+		return nil, fmt.Errorf("failed to ping database: %w", err)
+	}
+
+	logger.Info("Token repository initialized successfully")
+	return &tokenRepository{
+		db:     db,
+		logger: logger,
+	}, nil
+}
+
+// CreateRefreshToken stores a new refresh token with the access token issued alongside it
+// and fills in its creation time
+func (r *tokenRepository) CreateRefreshToken(ctx context.Context, token *schemas.RefreshTokenModel) error {
+	err := r.db.QueryRowContext(ctx, `
+		INSERT INTO task_management.refresh_tokens
+			(id, family_id, client_name, client_id, token_hash, expires_at, access_jti, access_expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING created_at
+	`,
+		token.ID,
+		token.FamilyID,
+		token.ClientName,
+		token.ClientID,
+		token.TokenHash,
+		token.ExpiresAt,
+		token.AccessTokenID,
+		token.AccessExpiresAt,
+	).Scan(&token.CreatedAt)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to create refresh token")
+		return fmt.Errorf("failed to create refresh token: %w", err)
+	}
+	return nil
+}
+
+// GetRefreshToken retrieves a refresh token by the hash of its value
+func (r *tokenRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*schemas.RefreshTokenModel, error) {
+	var token schemas.RefreshTokenModel
+	err := r.db.QueryRowContext(ctx, `
+		SELECT id, family_id, client_name, client_id, token_hash, expires_at, used_at, revoked_at, created_at
+		FROM task_management.refresh_tokens
+		WHERE token_hash = $1
+	`, tokenHash).Scan(
+		&token.ID,
+		&token.FamilyID,
+		&token.ClientName,
+		&token.ClientID,
+		&token.TokenHash,
+		&token.ExpiresAt,
+		&token.UsedAt,
+		&token.RevokedAt,
+		&token.CreatedAt,
+	)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, interfaces.ErrRefreshTokenNotFound
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to get refresh token")
+		return nil, fmt.Errorf("failed to get refresh token: %w", err)
+	}
+	return &token, nil
+}
+
+// UseRefreshToken marks a refresh token as exchanged. The condition on used_at makes the
+// update the lock: of two concurrent exchanges only one changes the row.
+func (r *tokenRepository) UseRefreshToken(ctx context.Context, id string) (bool, error) {
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE task_management.refresh_tokens
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND used_at IS NULL AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to use refresh token")
+		return false, fmt.Errorf("failed to use refresh token: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	return rows == 1, nil
+}
+
+// RevokeRefreshTokenFamily revokes every unrevoked refresh token of a family and adds the
+// access tokens issued with them to the revocation list. Both happen in one statement, so
+// no access token of a revoked family is left valid.
+func (r *tokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	var revoked, blocked int
+	err := r.db.QueryRowContext(ctx, `
+		WITH revoked AS (
+			UPDATE task_management.refresh_tokens
+			SET revoked_at = CURRENT_TIMESTAMP
+			WHERE family_id = $1 AND revoked_at IS NULL
+			RETURNING client_id, access_jti, access_expires_at
+		), blocked AS (
+			INSERT INTO task_management.revoked_tokens (jti, client_id, expires_at)
+			SELECT access_jti, client_id, access_expires_at
+			FROM revoked
+			WHERE access_jti IS NOT NULL AND access_expires_at > CURRENT_TIMESTAMP
+			ON CONFLICT (jti) DO NOTHING
+			RETURNING jti
+		)
+		SELECT (SELECT COUNT(*) FROM revoked), (SELECT COUNT(*) FROM blocked)
+	`, familyID).Scan(&revoked, &blocked)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to revoke refresh tokens")
+		return fmt.Errorf("failed to revoke refresh tokens: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"family_id":      familyID,
+		"revoked":        revoked,
+		"access_revoked": blocked,
+	}).Info("Refresh token family revoked")
+	return nil
+}
+
+// RevokeAccessToken adds an access token ID to the revocation list. Revoking a token twice
+// is not an error.
+func (r *tokenRepository) RevokeAccessToken(ctx context.Context, jti string, clientID string, expiresAt time.Time) error {
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO task_management.revoked_tokens (jti, client_id, expires_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (jti) DO NOTHING
+	`, jti, clientID, expiresAt)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to revoke access token")
+		return fmt.Errorf("failed to revoke access token: %w", err)
+	}
+
+	r.logger.WithFields(logrus.Fields{
+		"jti":       jti,
+		"client_id": clientID,
+	}).Info("Access token revoked")
+	return nil
+}
+
+// IsAccessTokenRevoked reports whether an access token ID is on the revocation list
+func (r *tokenRepository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	var revoked bool
+	err := r.db.QueryRowContext(ctx, `
+		SELECT EXISTS (
+			SELECT 1 FROM task_management.revoked_tokens WHERE jti = $1
+		)
+	`, jti).Scan(&revoked)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to check access token revocation")
+		return false, fmt.Errorf("failed to check access token revocation: %w", err)
+	}
+	return revoked, nil
+}
+
+// PurgeExpiredTokens deletes the refresh tokens and revocations whose tokens have expired.
+// A refresh token is kept while the access token issued with it is still valid.
+func (r *tokenRepository) PurgeExpiredTokens(ctx context.Context) (int64, error) {
+	var purged int64
+	err := r.db.QueryRowContext(ctx, `
+		WITH refresh AS (
+			DELETE FROM task_management.refresh_tokens
+			WHERE expires_at <= CURRENT_TIMESTAMP
+				AND (access_expires_at IS NULL OR access_expires_at <= CURRENT_TIMESTAMP)
+			RETURNING 1
+		), revocations AS (
+			DELETE FROM task_management.revoked_tokens
+			WHERE expires_at <= CURRENT_TIMESTAMP
+			RETURNING 1
+		)
+		SELECT (SELECT COUNT(*) FROM refresh) + (SELECT COUNT(*) FROM revocations)
+	`).Scan(&purged)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to purge expired tokens")
+		return 0, fmt.Errorf("failed to purge expired tokens: %w", err)
+	}
+	return purged, nil
+}
@@ -1,9 +1,181 @@
 package interfaces
 
 import (
+	"context"
+	"errors"
+	"time"
+
 	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	taskSchemas "taskmanager/Services/CommandServices/TaskCommandService/schemas"
 )
 
+// ErrImportJobNotFound is returned when a job does not exist for the requesting client
+var ErrImportJobNotFound = errors.New("import job not found")
+
+// ErrImportLedgerEntryNotFound is returned when the ledger has no matching import
+var ErrImportLedgerEntryNotFound = errors.New("import ledger entry not found")
+
+// ErrTaskNotFound is returned when an active task does not exist for the requesting client
+var ErrTaskNotFound = errors.New("task not found")
+
+// ErrDuplicateTask is returned when the client already has an active task with the same email
+var ErrDuplicateTask = errors.New("task with the same email already exists")
+
+// ErrTaskStatusChanged is returned when a task's status changed after it was read
+var ErrTaskStatusChanged = errors.New("task status changed concurrently")
+
+// ErrClientNotFound is returned when no client has the given ID
+var ErrClientNotFound = errors.New("client not found")
+
+// ErrDuplicateClient is returned when a client with the same ID or name already exists
+var ErrDuplicateClient = errors.New("client with the same ID or name already exists")
+
+// ErrRefreshTokenNotFound is returned when no refresh token has the given hash
+var ErrRefreshTokenNotFound = errors.New("refresh token not found")
+
 type TaskCommandRepository interface {
-	BulkCreateTasks(tasks []schemas.TaskModel) error
+	// BeginTaskImport opens a transaction that stores an import in batches
+	BeginTaskImport(ctx context.Context) (TaskBatchWriter, error)
+
+	// BeginTaskUpsert opens a transaction that stores an import in batches, updating the
+	// active task that already has a written task's client and email instead of adding
+	// another
+	BeginTaskUpsert(ctx context.Context) (TaskBatchWriter, error)
+
+	// CreateTask stores a single task and fills in its ID and timestamps
+	CreateTask(ctx context.Context, task *schemas.TaskModel) error
+
+	// GetTask retrieves an active task owned by the given client
+	GetTask(ctx context.Context, clientID string, id int) (*schemas.TaskModel, error)
+
+	// UpdateTask replaces the fields of an active task owned by the task's client and
+	// fills in its timestamps
+	UpdateTask(ctx context.Context, task *schemas.TaskModel) error
+
+	// PatchTask locks an active task owned by the given client, replaces its fields with
+	// those of the task patch returns for it and returns the stored task. Patches of the
+	// same task are applied one after the other, never to a stale copy.
+	PatchTask(
+		ctx context.Context,
+		clientID string,
+		id int,
+		patch func(current schemas.TaskModel) (*schemas.TaskModel, error),
+	) (*schemas.TaskModel, error)
+
+	// DeactivateTask soft deletes an active task owned by the given client
+	DeactivateTask(ctx context.Context, clientID string, id int) error
+
+	// GetTaskStatus returns the latest status of an active task owned by the given
+	// client, or an empty string if the task has no status history
+	GetTaskStatus(ctx context.Context, clientID string, taskID int) (string, error)
+
+	// AppendTaskStatus adds a status change to the history of an active task owned by the
+	// entry's client and fills in its ID and creation time. The change is refused with
+	// ErrTaskStatusChanged unless the latest status is still expected.
+	AppendTaskStatus(ctx context.Context, entry *taskSchemas.TaskStatusModel, expected string) error
+}
+
+// TaskBatchWriter stores the tasks of one import inside a single transaction
+type TaskBatchWriter interface {
+	// LockFile holds the import lock of a file's content for the client until the
+	// transaction ends, so imports of the same content run one at a time, and returns the
+	// latest successful import of that content, or ErrImportLedgerEntryNotFound
+	LockFile(ctx context.Context, clientID string, fileHash string) (*schemas.ImportLedgerModel, error)
+
+	// WriteBatch copies a batch of tasks into the transaction and reports how many were
+	// inserted, updated or left unchanged
+	WriteBatch(ctx context.Context, tasks []schemas.TaskModel) (schemas.ImportWriteCounts, error)
+
+	// RecordImport adds the ledger entry of the import to the transaction and fills in its
+	// ID, so that the entry is committed together with the tasks
+	RecordImport(ctx context.Context, entry *schemas.ImportLedgerModel) error
+
+	// Commit makes every written batch visible
+	Commit() error
+
+	// Rollback discards every written batch; it is a no-op after Commit
+	Rollback() error
+}
+
+// ImportJobRepository persists asynchronous import jobs and their progress
+type ImportJobRepository interface {
+	// CreateImportJob stores a new queued job and fills in its ID and creation time
+	CreateImportJob(ctx context.Context, job *schemas.ImportJobModel) error
+
+	// StartImportJob moves a queued job to running, reporting false if it was no longer queued
+	StartImportJob(ctx context.Context, jobID string) (bool, error)
+
+	// UpdateImportJobProgress records row counts for a running job
+	UpdateImportJobProgress(ctx context.Context, jobID string, progress schemas.ImportProgress) error
+
+	// FinishImportJob records the terminal status of a job that has not finished yet
+	FinishImportJob(ctx context.Context, jobID string, status string, errorMessage string) error
+
+	// CancelQueuedImportJob cancels a job that has not started, reporting false if it already had
+	CancelQueuedImportJob(ctx context.Context, jobID string, clientID string) (bool, error)
+
+	// GetImportJob retrieves a job owned by the given client
+	GetImportJob(ctx context.Context, jobID string, clientID string) (*schemas.ImportJobModel, error)
+
+	// FailInterruptedImportJobs marks jobs left queued or running by a previous process as failed
+	FailInterruptedImportJobs(ctx context.Context) (int64, error)
+}
+
+// ImportLedgerRepository records every imported file by content hash
+type ImportLedgerRepository interface {
+	// RecordImport stores a finished import and fills in its ID
+	RecordImport(ctx context.Context, entry *schemas.ImportLedgerModel) error
+
+	// ListImports returns the most recent imports of the client, newest first
+	ListImports(ctx context.Context, clientID string, limit int) ([]schemas.ImportLedgerModel, error)
+}
+
+// ClientRepository stores API clients and their hashed secrets
+type ClientRepository interface {
+	// CreateClient stores a new active client and fills in its timestamps, or returns
+	// ErrDuplicateClient
+	CreateClient(ctx context.Context, client *schemas.ClientModel) error
+
+	// GetClient retrieves a client, active or not, or ErrClientNotFound
+	GetClient(ctx context.Context, clientID string) (*schemas.ClientModel, error)
+
+	// UpdateClientSecret replaces the secret hash of a client and returns the client, or
+	// ErrClientNotFound
+	UpdateClientSecret(ctx context.Context, clientID string, secretHash string) (*schemas.ClientModel, error)
+
+	// UpdateClientScopes replaces the scopes of a client and returns the client, or
+	// ErrClientNotFound
+	UpdateClientScopes(ctx context.Context, clientID string, scopes []string) (*schemas.ClientModel, error)
+
+	// DisableClient deactivates a client and returns it, or ErrClientNotFound
+	DisableClient(ctx context.Context, clientID string) (*schemas.ClientModel, error)
+}
+
+// TokenRepository stores refresh tokens and the access tokens revoked before they expire
+type TokenRepository interface {
+	// CreateRefreshToken stores a new refresh token with the access token issued alongside
+	// it and fills in its creation time
+	CreateRefreshToken(ctx context.Context, token *schemas.RefreshTokenModel) error
+
+	// GetRefreshToken retrieves a refresh token by the hash of its value, or
+	// ErrRefreshTokenNotFound
+	GetRefreshToken(ctx context.Context, tokenHash string) (*schemas.RefreshTokenModel, error)
+
+	// UseRefreshToken marks a refresh token as exchanged, reporting false if it had already
+	// been used or revoked
+	UseRefreshToken(ctx context.Context, id string) (bool, error)
+
+	// RevokeRefreshTokenFamily revokes every unrevoked refresh token of a family together
+	// with the access tokens issued with them
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+
+	// RevokeAccessToken adds an access token ID to the revocation list until it expires
+	RevokeAccessToken(ctx context.Context, jti string, clientID string, expiresAt time.Time) error
+
+	// IsAccessTokenRevoked reports whether an access token ID is on the revocation list
+	IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error)
+
+	// PurgeExpiredTokens deletes expired refresh tokens and revocations of expired access
+	// tokens, returning how many rows were deleted
+	PurgeExpiredTokens(ctx context.Context) (int64, error)
 }
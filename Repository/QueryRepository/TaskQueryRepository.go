@@ -3,7 +3,9 @@ package QueryRepository
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"taskmanager/Repository/QueryRepository/interfaces"
 	"taskmanager/RequestControllers/httpSetup/config"
 	"time"
@@ -39,29 +41,167 @@ func NewTaskQueryRepository(cfg *config.DatabaseConfig, logger *logrus.Logger) (
 	}, nil
 }
 
-// GetActiveTasks retrieves active tasks for a specific client
-func (r *taskQueryRepository) GetActiveTasks(ctx context.Context, clientName string, clientID string) ([]interfaces.TaskDTO, error) {
+// sortColumns maps each sort field to its column and the type its cursor value is cast to
+var sortColumns = map[string]struct {
+	column string
+	cast   string
+}{
+	interfaces.SortByID:        {"t.id", "integer"},
+	interfaces.SortByName:      {"t.name", "text"},
+	interfaces.SortByHireDate:  {"t.hire_date", "date"},
+	interfaces.SortBySalary:    {"t.salary", "numeric"},
+	interfaces.SortByCreatedAt: {"t.created_at", "timestamp"},
+}
+
+// queryBuilder numbers the parameters of a query as its conditions are added
+type queryBuilder struct {
+	conditions []string
+	params     []interface{}
+}
+
+// param adds a parameter and returns its placeholder
+func (b *queryBuilder) param(value interface{}) string {
+	b.params = append(b.params, value)
+	return fmt.Sprintf("$%d", len(b.params))
+}
+
+// where adds a condition built around the placeholder of value
+func (b *queryBuilder) where(format string, value interface{}) {
+	b.conditions = append(b.conditions, fmt.Sprintf(format, b.param(value)))
+}
+
+// selectTasks selects tasks as t with their latest status row as latest, followed by any
+// extra columns. Tasks without status history get the status bound to the initialStatus
+// placeholder.
+func selectTasks(initialStatus string, extra ...string) string {
+	var extraColumns string
+	for _, column := range extra {
+		extraColumns += ", " + column
+	}
+
+	return `
+		SELECT 
+			t.id, t.name, t.email, t.age, t.address, t.phone_number,
+			t.department, t.position, t.salary, t.client_name,
+			t.client_id, t.is_active, t.hire_date, t.created_at, t.updated_at,
+			COALESCE(latest.status, ` + initialStatus + `), COALESCE(latest.status_description, ''),
+			latest.created_at` + extraColumns + `
+		FROM task_management.tasks t
+		LEFT JOIN LATERAL (
+			SELECT s.status, s.status_description, s.created_at
+			FROM task_management.task_status s
+			WHERE s.task_id = t.id
+			ORDER BY s.created_at DESC, s.id DESC
+			LIMIT 1
+		) latest ON true`
+}
+
+// scanTask reads a row selected by selectTasks into a task and the extra destinations
+func scanTask(row interface{ Scan(dest ...any) error }, extra ...any) (*interfaces.TaskDTO, error) {
+	var task interfaces.TaskDTO
+	var statusUpdatedAt sql.NullTime
+	dest := []any{
+		&task.ID,
+		&task.Name,
+		&task.Email,
+		&task.Age,
+		&task.Address,
+		&task.PhoneNumber,
+		&task.Department,
+		&task.Position,
+		&task.Salary,
+		&task.ClientName,
+		&task.ClientID,
+		&task.IsActive,
+		&task.HireDate,
+		&task.CreatedAt,
+		&task.UpdatedAt,
+		&task.CurrentStatus,
+		&task.StatusDescription,
+		&statusUpdatedAt,
+	}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+
+	if statusUpdatedAt.Valid {
+		task.StatusUpdatedAt = &statusUpdatedAt.Time
+	}
+	return &task, nil
+}
+
+// GetActiveTasks retrieves one page of active tasks for a specific client with their
+// current status
+func (r *taskQueryRepository) GetActiveTasks(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	filter interfaces.TaskFilter,
+	page interfaces.TaskPage,
+) ([]interfaces.TaskDTO, error) {
 	r.logger.WithFields(logrus.Fields{
 		"client_name": clientName,
 		"client_id":   clientID,
+		"filter":      filter,
+		"sort":        page.SortBy,
 	}).Debug("Querying active tasks")
-	query := `
-		SELECT 
-			id, name, email, age, address, phone_number,
-			department, position, salary, client_name,
-			client_id, is_active
-		FROM task_management.tasks
-		WHERE client_name = $1 
-		AND client_id = $2
-		AND is_active = true
-	`
+
+	sortBy := page.SortBy
+	if sortBy == "" {
+		sortBy = interfaces.SortByID
+	}
+	sort, ok := sortColumns[sortBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported sort field %q", sortBy)
+	}
+
+	var b queryBuilder
+	initialStatus := b.param(interfaces.InitialStatus)
+	b.where("t.client_name = %s", clientName)
+	b.where("t.client_id = %s", clientID)
+	b.conditions = append(b.conditions, "t.is_active = true")
+	if filter.Status != "" {
+		b.where("COALESCE(latest.status, "+initialStatus+") = %s", filter.Status)
+	}
+	if filter.Department != "" {
+		b.where("LOWER(t.department) = LOWER(%s)", filter.Department)
+	}
+	if filter.Position != "" {
+		b.where("LOWER(t.position) = LOWER(%s)", filter.Position)
+	}
+	if filter.HiredFrom != nil {
+		b.where("t.hire_date >= %s", *filter.HiredFrom)
+	}
+	if filter.HiredTo != nil {
+		b.where("t.hire_date <= %s", *filter.HiredTo)
+	}
+	if filter.MinSalary != nil {
+		b.where("t.salary >= %s", *filter.MinSalary)
+	}
+	if filter.MaxSalary != nil {
+		b.where("t.salary <= %s", *filter.MaxSalary)
+	}
+
+	direction, comparison := "ASC", ">"
+	if page.Descending {
+		direction, comparison = "DESC", "<"
+	}
+	if page.After != nil {
+		value := b.param(page.After.SortValue)
+		b.where(fmt.Sprintf("(%s, t.id) %s (%s::%s, %%s)", sort.column, comparison, value, sort.cast), page.After.ID)
+	}
+
+	query := selectTasks(initialStatus) + `
+		WHERE ` + strings.Join(b.conditions, "\n\t\tAND ") + `
+		ORDER BY ` + sort.column + ` ` + direction + `, t.id ` + direction + `
+		LIMIT ` + b.param(page.Limit)
 
 	r.logger.WithFields(logrus.Fields{
 		"query":  query,
-		"params": []interface{}{clientName, clientID},
+		"params": b.params,
 	}).Debug("Executing query")
 
-	rows, err := r.db.QueryContext(ctx, query, clientName, clientID)
+	rows, err := r.db.QueryContext(ctx, query, b.params...)
 	if err != nil {
 		r.logger.WithError(err).Error("Failed to query active tasks")
 		return nil, fmt.Errorf("failed to query active tasks: %w", err)
@@ -70,26 +210,12 @@ func (r *taskQueryRepository) GetActiveTasks(ctx context.Context, clientName str
 
 	var tasks []interfaces.TaskDTO
 	for rows.Next() {
-		var task interfaces.TaskDTO
-		err := rows.Scan(
-			&task.ID,
-			&task.Name,
-			&task.Email,
-			&task.Age,
-			&task.Address,
-			&task.PhoneNumber,
-			&task.Department,
-			&task.Position,
-			&task.Salary,
-			&task.ClientName,
-			&task.ClientID,
-			&task.IsActive,
-		)
+		task, err := scanTask(rows)
 		if err != nil {
 			r.logger.WithError(err).Error("Failed to scan task row")
 			return nil, fmt.Errorf("failed to scan task row: %w", err)
 		}
-		tasks = append(tasks, task)
+		tasks = append(tasks, *task)
 	}
 
 	if err = rows.Err(); err != nil {
@@ -100,24 +226,38 @@ func (r *taskQueryRepository) GetActiveTasks(ctx context.Context, clientName str
 	return tasks, nil
 }
 
-// GetTaskStatusHistory retrieves status history for a specific client
-func (r *taskQueryRepository) GetTaskStatusHistory(ctx context.Context, clientName string, clientID string) ([]interfaces.TaskStatusDTO, error) {
+// GetTaskStatusHistory retrieves one page of status history for a specific client,
+// newest first
+func (r *taskQueryRepository) GetTaskStatusHistory(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	page interfaces.HistoryPage,
+) ([]interfaces.TaskStatusDTO, error) {
 	r.logger.WithFields(logrus.Fields{
 		"client_name": clientName,
 		"client_id":   clientID,
 	}).Debug("Querying task status history")
+
+	var b queryBuilder
+	b.where("client_name = %s", clientName)
+	b.where("client_id = %s", clientID)
+	if page.After != nil {
+		createdAt := b.param(page.After.CreatedAt)
+		b.where("(created_at, id) < ("+createdAt+", %s)", page.After.ID)
+	}
+
 	query := `
 		SELECT 
-			task_id, client_name, client_id, status,
-			status_description, updated_by, 
+			id, task_id, client_name, client_id, status,
+			COALESCE(status_description, ''), COALESCE(updated_by, ''), 
 			created_at
 		FROM task_management.task_status
-		WHERE client_name = $1 
-		AND client_id = $2
-		ORDER BY created_at DESC
-	`
+		WHERE ` + strings.Join(b.conditions, "\n\t\tAND ") + `
+		ORDER BY created_at DESC, id DESC
+		LIMIT ` + b.param(page.Limit)
 
-	rows, err := r.db.QueryContext(ctx, query, clientName, clientID)
+	rows, err := r.db.QueryContext(ctx, query, b.params...)
 	if err != nil {
 		r.logger.WithError(err).Error("Failed to query task status history")
 		return nil, fmt.Errorf("failed to query task status history: %w", err)
@@ -128,6 +268,7 @@ func (r *taskQueryRepository) GetTaskStatusHistory(ctx context.Context, clientNa
 	for rows.Next() {
 		var status interfaces.TaskStatusDTO
 		err := rows.Scan(
+			&status.ID,
 			&status.TaskID,
 			&status.ClientName,
 			&status.ClientID,
@@ -150,3 +291,434 @@ func (r *taskQueryRepository) GetTaskStatusHistory(ctx context.Context, clientNa
 	r.logger.WithField("history_count", len(statusHistory)).Info("Retrieved status history")
 	return statusHistory, nil
 }
+
+// GetTask retrieves a task owned by a specific client, active or not, with its current status
+func (r *taskQueryRepository) GetTask(ctx context.Context, clientName string, clientID string, id int) (*interfaces.TaskDTO, error) {
+	r.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"task_id":     id,
+	}).Debug("Querying task")
+
+	query := selectTasks("$1") + `
+		WHERE t.client_name = $2
+		AND t.client_id = $3
+		AND t.id = $4
+	`
+
+	task, err := scanTask(r.db.QueryRowContext(ctx, query, interfaces.InitialStatus, clientName, clientID, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, interfaces.ErrTaskNotFound
+	}
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query task")
+		return nil, fmt.Errorf("failed to query task: %w", err)
+	}
+
+	return task, nil
+}
+
+// GetTaskTimeline retrieves every status change of a client's task, oldest first
+func (r *taskQueryRepository) GetTaskTimeline(ctx context.Context, clientID string, taskID int) ([]interfaces.TaskStatusDTO, error) {
+	query := `
+		SELECT 
+			id, task_id, client_name, client_id, status,
+			COALESCE(status_description, ''), COALESCE(updated_by, ''), 
+			created_at
+		FROM task_management.task_status
+		WHERE client_id = $1
+		AND task_id = $2
+		ORDER BY created_at, id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, clientID, taskID)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query task timeline")
+		return nil, fmt.Errorf("failed to query task timeline: %w", err)
+	}
+	defer rows.Close()
+
+	timeline := []interfaces.TaskStatusDTO{}
+	for rows.Next() {
+		var status interfaces.TaskStatusDTO
+		err := rows.Scan(
+			&status.ID,
+			&status.TaskID,
+			&status.ClientName,
+			&status.ClientID,
+			&status.Status,
+			&status.StatusDescription,
+			&status.UpdatedBy,
+			&status.CreatedAt,
+		)
+		if err != nil {
+			r.logger.WithError(err).Error("Failed to scan status row")
+			return nil, fmt.Errorf("failed to scan status row: %w", err)
+		}
+		timeline = append(timeline, status)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithError(err).Error("Error during row iteration")
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+	return timeline, nil
+}
+
+// searchDocument is the text search document of a task t. It must match the expression of
+// the idx_tasks_search_vector index for the index to be used.
+const searchDocument = `to_tsvector('simple', COALESCE(t.name, '') || ' ' || COALESCE(t.email, '') || ' ' || COALESCE(t.address, ''))`
+
+// likePattern escapes the LIKE wildcards of text and matches it anywhere in a value
+func likePattern(text string) string {
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(text) + "%"
+}
+
+// SearchTasks retrieves one page of a client's active tasks whose name, email or address
+// match a search text, most relevant first. A task matches when it contains every word of
+// the text or contains the text itself; its rank adds the full-text rank to the best
+// trigram similarity of the three fields.
+func (r *taskQueryRepository) SearchTasks(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	text string,
+	page interfaces.SearchPage,
+) ([]interfaces.TaskSearchResult, error) {
+	r.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"text":        text,
+	}).Debug("Searching tasks")
+
+	var b queryBuilder
+	initialStatus := b.param(interfaces.InitialStatus)
+	search := b.param(text)
+	pattern := b.param(likePattern(text))
+	b.where("t.client_name = %s", clientName)
+	b.where("t.client_id = %s", clientID)
+	b.conditions = append(b.conditions, "t.is_active = true")
+	b.conditions = append(b.conditions, fmt.Sprintf(
+		"(%s @@ plainto_tsquery('simple', %s) OR t.name ILIKE %s OR t.email ILIKE %s OR t.address ILIKE %s)",
+		searchDocument, search, pattern, pattern, pattern,
+	))
+
+	var after string
+	if page.After != nil {
+		rank := b.param(page.After.Rank)
+		after = `
+		WHERE m.rank < ` + rank + `::real OR (m.rank = ` + rank + `::real AND t.id > ` + b.param(page.After.ID) + `)`
+	}
+
+	query := `
+		WITH matches AS (
+			SELECT t.id,
+				ts_rank(` + searchDocument + `, plainto_tsquery('simple', ` + search + `))
+				+ GREATEST(similarity(t.name, ` + search + `), similarity(t.email, ` + search + `), similarity(t.address, ` + search + `)) AS rank
+			FROM task_management.tasks t
+			WHERE ` + strings.Join(b.conditions, "\n\t\t\tAND ") + `
+		)` + selectTasks(initialStatus, "m.rank") + `
+		JOIN matches m ON m.id = t.id` + after + `
+		ORDER BY m.rank DESC, t.id
+		LIMIT ` + b.param(page.Limit)
+
+	r.logger.WithFields(logrus.Fields{
+		"query":  query,
+		"params": b.params,
+	}).Debug("Executing query")
+
+	rows, err := r.db.QueryContext(ctx, query, b.params...)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to search tasks")
+		return nil, fmt.Errorf("failed to search tasks: %w", err)
+	}
+	defer rows.Close()
+
+	var results []interfaces.TaskSearchResult
+	for rows.Next() {
+		var rank float64
+		task, err := scanTask(rows, &rank)
+		if err != nil {
+			r.logger.WithError(err).Error("Failed to scan task row")
+			return nil, fmt.Errorf("failed to scan task row: %w", err)
+		}
+		results = append(results, interfaces.TaskSearchResult{TaskDTO: *task, Rank: rank})
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithError(err).Error("Error during row iteration")
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+	r.logger.WithField("result_count", len(results)).Info("Retrieved task search results")
+	return results, nil
+}
+
+// groupColumns maps each report grouping to its column
+var groupColumns = map[string]string{
+	interfaces.GroupByDepartment: "t.department",
+	interfaces.GroupByPosition:   "t.position",
+}
+
+// GetGroupReport aggregates headcount, salary and age of a client's active tasks per
+// department or position, ordered by group
+func (r *taskQueryRepository) GetGroupReport(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	groupBy string,
+) ([]interfaces.GroupReportDTO, error) {
+	r.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"group_by":    groupBy,
+	}).Debug("Querying group report")
+
+	column, ok := groupColumns[groupBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported report grouping %q", groupBy)
+	}
+
+	query := `
+		SELECT 
+			` + column + `, COUNT(*),
+			ROUND(AVG(t.salary), 2), MIN(t.salary), MAX(t.salary), SUM(t.salary),
+			ROUND(AVG(t.age), 2)
+		FROM task_management.tasks t
+		WHERE t.client_name = $1
+		AND t.client_id = $2
+		AND t.is_active = true
+		GROUP BY ` + column + `
+		ORDER BY ` + column
+
+	rows, err := r.db.QueryContext(ctx, query, clientName, clientID)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query group report")
+		return nil, fmt.Errorf("failed to query group report: %w", err)
+	}
+	defer rows.Close()
+
+	report := []interfaces.GroupReportDTO{}
+	for rows.Next() {
+		var group interfaces.GroupReportDTO
+		err := rows.Scan(
+			&group.Group,
+			&group.Headcount,
+			&group.AverageSalary,
+			&group.MinSalary,
+			&group.MaxSalary,
+			&group.TotalSalary,
+			&group.AverageAge,
+		)
+		if err != nil {
+			r.logger.WithError(err).Error("Failed to scan report row")
+			return nil, fmt.Errorf("failed to scan report row: %w", err)
+		}
+		report = append(report, group)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithError(err).Error("Error during row iteration")
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+	r.logger.WithField("group_count", len(report)).Info("Retrieved group report")
+	return report, nil
+}
+
+// GetHireReport counts a client's active tasks by hire month or year, oldest first
+func (r *taskQueryRepository) GetHireReport(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	period string,
+) ([]interfaces.HireCountDTO, error) {
+	r.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"period":      period,
+	}).Debug("Querying hire report")
+
+	if period != interfaces.PeriodMonth && period != interfaces.PeriodYear {
+		return nil, fmt.Errorf("unsupported report period %q", period)
+	}
+
+	query := `
+		SELECT DATE_TRUNC($3, t.hire_date::timestamp)::date AS period, COUNT(*)
+		FROM task_management.tasks t
+		WHERE t.client_name = $1
+		AND t.client_id = $2
+		AND t.is_active = true
+		GROUP BY period
+		ORDER BY period
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, clientName, clientID, period)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query hire report")
+		return nil, fmt.Errorf("failed to query hire report: %w", err)
+	}
+	defer rows.Close()
+
+	report := []interfaces.HireCountDTO{}
+	for rows.Next() {
+		var count interfaces.HireCountDTO
+		if err := rows.Scan(&count.Period, &count.Hires); err != nil {
+			r.logger.WithError(err).Error("Failed to scan report row")
+			return nil, fmt.Errorf("failed to scan report row: %w", err)
+		}
+		report = append(report, count)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithError(err).Error("Error during row iteration")
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+	r.logger.WithField("period_count", len(report)).Info("Retrieved hire report")
+	return report, nil
+}
+
+// GetStatusDurations summarises how long a client's tasks stayed in each status before
+// their next status change, ordered by status
+func (r *taskQueryRepository) GetStatusDurations(ctx context.Context, clientName string, clientID string) ([]interfaces.StatusDurationDTO, error) {
+	r.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+	}).Debug("Querying status durations")
+
+	query := `
+		WITH stays AS (
+			SELECT 
+				s.status,
+				EXTRACT(EPOCH FROM LEAD(s.created_at) OVER (
+					PARTITION BY s.task_id ORDER BY s.created_at, s.id
+				) - s.created_at) AS seconds
+			FROM task_management.task_status s
+			WHERE s.client_name = $1
+			AND s.client_id = $2
+		)
+		SELECT 
+			status, COUNT(*), AVG(seconds),
+			PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY seconds),
+			PERCENTILE_CONT(0.9) WITHIN GROUP (ORDER BY seconds),
+			PERCENTILE_CONT(0.95) WITHIN GROUP (ORDER BY seconds)
+		FROM stays
+		WHERE seconds IS NOT NULL
+		GROUP BY status
+		ORDER BY status
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, clientName, clientID)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query status durations")
+		return nil, fmt.Errorf("failed to query status durations: %w", err)
+	}
+	defer rows.Close()
+
+	durations := []interfaces.StatusDurationDTO{}
+	for rows.Next() {
+		var duration interfaces.StatusDurationDTO
+		err := rows.Scan(
+			&duration.Status,
+			&duration.Count,
+			&duration.AverageSeconds,
+			&duration.MedianSeconds,
+			&duration.P90Seconds,
+			&duration.P95Seconds,
+		)
+		if err != nil {
+			r.logger.WithError(err).Error("Failed to scan duration row")
+			return nil, fmt.Errorf("failed to scan duration row: %w", err)
+		}
+		durations = append(durations, duration)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithError(err).Error("Error during row iteration")
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+	return durations, nil
+}
+
+// GetCompletionThroughput counts the tasks a client completed in each of the last weeks,
+// oldest first, including weeks without completions. The current week is the last one.
+func (r *taskQueryRepository) GetCompletionThroughput(ctx context.Context, clientName string, clientID string, weeks int) ([]interfaces.WeeklyCountDTO, error) {
+	r.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"weeks":       weeks,
+	}).Debug("Querying completion throughput")
+
+	query := `
+		SELECT w.week_start::date, COUNT(DISTINCT s.task_id)
+		FROM GENERATE_SERIES(
+			DATE_TRUNC('week', NOW()) - ($3::integer - 1) * INTERVAL '1 week',
+			DATE_TRUNC('week', NOW()),
+			INTERVAL '1 week'
+		) AS w(week_start)
+		LEFT JOIN task_management.task_status s
+			ON s.client_name = $1
+			AND s.client_id = $2
+			AND s.status = 'COMPLETED'
+			AND s.created_at >= w.week_start
+			AND s.created_at < w.week_start + INTERVAL '1 week'
+		GROUP BY w.week_start
+		ORDER BY w.week_start
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, clientName, clientID, weeks)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to query completion throughput")
+		return nil, fmt.Errorf("failed to query completion throughput: %w", err)
+	}
+	defer rows.Close()
+
+	throughput := []interfaces.WeeklyCountDTO{}
+	for rows.Next() {
+		var week interfaces.WeeklyCountDTO
+		if err := rows.Scan(&week.WeekStart, &week.Completed); err != nil {
+			r.logger.WithError(err).Error("Failed to scan throughput row")
+			return nil, fmt.Errorf("failed to scan throughput row: %w", err)
+		}
+		throughput = append(throughput, week)
+	}
+
+	if err = rows.Err(); err != nil {
+		r.logger.WithError(err).Error("Error during row iteration")
+		return nil, fmt.Errorf("error during row iteration: %w", err)
+	}
+	return throughput, nil
+}
+
+// CountStuckTasks counts a client's active tasks that have been IN_PROGRESS for longer
+// than stuckAfter
+func (r *taskQueryRepository) CountStuckTasks(ctx context.Context, clientName string, clientID string, stuckAfter time.Duration) (int, error) {
+	r.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"stuck_after": stuckAfter.String(),
+	}).Debug("Counting stuck tasks")
+
+	query := `
+		SELECT COUNT(*)
+		FROM task_management.tasks t
+		JOIN LATERAL (
+			SELECT s.status, s.created_at
+			FROM task_management.task_status s
+			WHERE s.task_id = t.id
+			ORDER BY s.created_at DESC, s.id DESC
+			LIMIT 1
+		) latest ON true
+		WHERE t.client_name = $1
+		AND t.client_id = $2
+		AND t.is_active = true
+		AND latest.status = 'IN_PROGRESS'
+		AND latest.created_at < NOW() - MAKE_INTERVAL(secs => $3)
+	`
+
+	var count int
+	err := r.db.QueryRowContext(ctx, query, clientName, clientID, stuckAfter.Seconds()).Scan(&count)
+	if err != nil {
+		r.logger.WithError(err).Error("Failed to count stuck tasks")
+		return 0, fmt.Errorf("failed to count stuck tasks: %w", err)
+	}
+	return count, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"testing"
+	"time"
 
 	"taskmanager/Repository/QueryRepository/interfaces"
 	"taskmanager/RequestControllers/httpSetup/config"
@@ -19,6 +20,9 @@ const (
 	clientTwoUUID = "34fb4178-bee7-4c5d-b13c-7a4ac405d56d"
 )
 
+// testTaskPage is large enough to hold every seeded task of a client
+var testTaskPage = interfaces.TaskPage{Limit: 100, SortBy: interfaces.SortByID}
+
 func setupTestDB(t *testing.T) (*sql.DB, *logrus.Logger) {
 	// Initialize logger
 	logger := logrus.New()
@@ -54,11 +58,13 @@ func TestGetActiveTasks(t *testing.T) {
 		db:     db,
 		logger: logger,
 	}
+	minSalary := 70000.0
 
 	tests := []struct {
 		name       string
 		clientName string
 		clientID   string
+		filter     interfaces.TaskFilter
 		wantErr    bool
 		setup      func(t *testing.T, db *sql.DB)
 		verify     func(t *testing.T, tasks []interfaces.TaskDTO, err error)
@@ -76,6 +82,32 @@ func TestGetActiveTasks(t *testing.T) {
 				for _, task := range tasks {
 					assert.True(t, task.IsActive)
 					assert.Equal(t, "Client One Corp", task.ClientName)
+					assert.NotEmpty(t, task.CurrentStatus)
+				}
+			},
+		},
+		{
+			name:       "Filter by current status",
+			clientName: "Client One Corp",
+			clientID:   clientOneUUID,
+			filter:     interfaces.TaskFilter{Status: "IN_PROGRESS"},
+			verify: func(t *testing.T, tasks []interfaces.TaskDTO, err error) {
+				assert.NoError(t, err)
+				for _, task := range tasks {
+					assert.Equal(t, "IN_PROGRESS", task.CurrentStatus)
+					assert.NotNil(t, task.StatusUpdatedAt)
+				}
+			},
+		},
+		{
+			name:       "Filter by salary range",
+			clientName: "Client One Corp",
+			clientID:   clientOneUUID,
+			filter:     interfaces.TaskFilter{MinSalary: &minSalary},
+			verify: func(t *testing.T, tasks []interfaces.TaskDTO, err error) {
+				assert.NoError(t, err)
+				for _, task := range tasks {
+					assert.GreaterOrEqual(t, task.Salary, minSalary)
 				}
 			},
 		},
@@ -97,7 +129,7 @@ func TestGetActiveTasks(t *testing.T) {
 				tt.setup(t, db)
 			}
 
-			tasks, err := repo.GetActiveTasks(context.Background(), tt.clientName, tt.clientID)
+			tasks, err := repo.GetActiveTasks(context.Background(), tt.clientName, tt.clientID, tt.filter, testTaskPage)
 			if tt.verify != nil {
 				tt.verify(t, tasks, err)
 			} else {
@@ -111,6 +143,180 @@ func TestGetActiveTasks(t *testing.T) {
 	}
 }
 
+func TestGetActiveTasksPagination(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer db.Close()
+
+	repo := &taskQueryRepository{
+		db:     db,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	all, err := repo.GetActiveTasks(ctx, "Client One Corp", clientOneUUID, interfaces.TaskFilter{},
+		interfaces.TaskPage{Limit: 100, SortBy: interfaces.SortBySalary, Descending: true})
+	assert.NoError(t, err)
+
+	var paged []interfaces.TaskDTO
+	page := interfaces.TaskPage{Limit: 1, SortBy: interfaces.SortBySalary, Descending: true}
+	for len(paged) < len(all)+1 {
+		tasks, err := repo.GetActiveTasks(ctx, "Client One Corp", clientOneUUID, interfaces.TaskFilter{}, page)
+		assert.NoError(t, err)
+		if len(tasks) == 0 {
+			break
+		}
+		paged = append(paged, tasks...)
+		last := tasks[len(tasks)-1]
+		page.After = &interfaces.TaskCursor{SortValue: interfaces.FormatSortValue(last, page.SortBy), ID: last.ID}
+	}
+
+	assert.Equal(t, all, paged)
+}
+
+func TestGetTask(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer db.Close()
+
+	repo := &taskQueryRepository{
+		db:     db,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	tasks, err := repo.GetActiveTasks(ctx, "Client One Corp", clientOneUUID, interfaces.TaskFilter{}, testTaskPage)
+	assert.NoError(t, err)
+	if !assert.NotEmpty(t, tasks) {
+		return
+	}
+
+	task, err := repo.GetTask(ctx, "Client One Corp", clientOneUUID, tasks[0].ID)
+	assert.NoError(t, err)
+	assert.Equal(t, tasks[0], *task)
+
+	timeline, err := repo.GetTaskTimeline(ctx, clientOneUUID, task.ID)
+	assert.NoError(t, err)
+	for i := 1; i < len(timeline); i++ {
+		assert.False(t, timeline[i].CreatedAt.Before(timeline[i-1].CreatedAt))
+	}
+
+	_, err = repo.GetTask(ctx, "Client Two LLC", clientTwoUUID, task.ID)
+	assert.ErrorIs(t, err, interfaces.ErrTaskNotFound)
+}
+
+func TestSearchTasks(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer db.Close()
+
+	repo := &taskQueryRepository{
+		db:     db,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	tasks, err := repo.GetActiveTasks(ctx, "Client One Corp", clientOneUUID, interfaces.TaskFilter{}, testTaskPage)
+	assert.NoError(t, err)
+	if !assert.NotEmpty(t, tasks) {
+		return
+	}
+
+	results, err := repo.SearchTasks(ctx, "Client One Corp", clientOneUUID, tasks[0].Email, interfaces.SearchPage{Limit: 100})
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, results) {
+		assert.Equal(t, tasks[0].ID, results[0].ID)
+	}
+	for i := 1; i < len(results); i++ {
+		assert.LessOrEqual(t, results[i].Rank, results[i-1].Rank)
+	}
+
+	var paged []interfaces.TaskSearchResult
+	page := interfaces.SearchPage{Limit: 1}
+	for len(paged) < len(results)+1 {
+		next, err := repo.SearchTasks(ctx, "Client One Corp", clientOneUUID, tasks[0].Email, page)
+		assert.NoError(t, err)
+		if len(next) == 0 {
+			break
+		}
+		paged = append(paged, next...)
+		last := next[len(next)-1]
+		page.After = &interfaces.SearchCursor{Rank: last.Rank, ID: last.ID}
+	}
+	assert.Equal(t, results, paged)
+
+	other, err := repo.SearchTasks(ctx, "Client Two LLC", clientTwoUUID, tasks[0].Email, interfaces.SearchPage{Limit: 100})
+	assert.NoError(t, err)
+	for _, result := range other {
+		assert.NotEqual(t, tasks[0].ID, result.ID)
+	}
+}
+
+func TestGetReports(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer db.Close()
+
+	repo := &taskQueryRepository{
+		db:     db,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	tasks, err := repo.GetActiveTasks(ctx, "Client One Corp", clientOneUUID, interfaces.TaskFilter{}, testTaskPage)
+	assert.NoError(t, err)
+
+	for _, groupBy := range []string{interfaces.GroupByDepartment, interfaces.GroupByPosition} {
+		report, err := repo.GetGroupReport(ctx, "Client One Corp", clientOneUUID, groupBy)
+		assert.NoError(t, err)
+
+		headcount := 0
+		for _, group := range report {
+			headcount += group.Headcount
+			assert.LessOrEqual(t, group.MinSalary, group.MaxSalary)
+		}
+		assert.Equal(t, len(tasks), headcount)
+	}
+
+	for _, period := range []string{interfaces.PeriodMonth, interfaces.PeriodYear} {
+		report, err := repo.GetHireReport(ctx, "Client One Corp", clientOneUUID, period)
+		assert.NoError(t, err)
+
+		hires := 0
+		for _, count := range report {
+			hires += count.Hires
+		}
+		assert.Equal(t, len(tasks), hires)
+	}
+}
+
+func TestGetStatusAnalytics(t *testing.T) {
+	db, logger := setupTestDB(t)
+	defer db.Close()
+
+	repo := &taskQueryRepository{
+		db:     db,
+		logger: logger,
+	}
+	ctx := context.Background()
+
+	durations, err := repo.GetStatusDurations(ctx, "Client One Corp", clientOneUUID)
+	assert.NoError(t, err)
+	for _, duration := range durations {
+		assert.LessOrEqual(t, duration.MedianSeconds, duration.P90Seconds)
+		assert.LessOrEqual(t, duration.P90Seconds, duration.P95Seconds)
+	}
+
+	throughput, err := repo.GetCompletionThroughput(ctx, "Client One Corp", clientOneUUID, 4)
+	assert.NoError(t, err)
+	if assert.Len(t, throughput, 4) {
+		assert.Equal(t, time.Monday, throughput[3].WeekStart.Weekday())
+	}
+
+	stuck, err := repo.CountStuckTasks(ctx, "Client One Corp", clientOneUUID, time.Hour)
+	assert.NoError(t, err)
+	never, err := repo.CountStuckTasks(ctx, "Client One Corp", clientOneUUID, 100*365*24*time.Hour)
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, stuck, never)
+	assert.Zero(t, never)
+}
+
 func TestGetTaskStatusHistory(t *testing.T) {
 	db, logger := setupTestDB(t)
 	defer db.Close()
@@ -160,7 +366,7 @@ func TestGetTaskStatusHistory(t *testing.T) {
 				tt.setup(t, db)
 			}
 
-			history, err := repo.GetTaskStatusHistory(context.Background(), tt.clientName, tt.clientID)
+			history, err := repo.GetTaskStatusHistory(context.Background(), tt.clientName, tt.clientID, interfaces.HistoryPage{Limit: 100})
 			if tt.verify != nil {
 				tt.verify(t, history, err)
 			} else {
@@ -2,36 +2,216 @@ package interfaces
 
 import (
 	"context"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
 	"time"
+	"unicode/utf8"
 )
 
 // TaskQueryRepository defines the methods for querying tasks
 type TaskQueryRepository interface {
-	// GetActiveTasks retrieves active tasks for a specific client
-	GetActiveTasks(ctx context.Context, clientName string, clientID string) ([]TaskDTO, error)
+	// GetActiveTasks retrieves one page of active tasks for a specific client with their
+	// current status
+	GetActiveTasks(ctx context.Context, clientName string, clientID string, filter TaskFilter, page TaskPage) ([]TaskDTO, error)
 
-	// GetTaskStatusHistory retrieves status history for a specific client
-	GetTaskStatusHistory(ctx context.Context, clientName string, clientID string) ([]TaskStatusDTO, error)
+	// GetTaskStatusHistory retrieves one page of status history for a specific client,
+	// newest first
+	GetTaskStatusHistory(ctx context.Context, clientName string, clientID string, page HistoryPage) ([]TaskStatusDTO, error)
+
+	// GetTask retrieves a task owned by a specific client, active or not, with its current
+	// status, or ErrTaskNotFound
+	GetTask(ctx context.Context, clientName string, clientID string, id int) (*TaskDTO, error)
+
+	// GetTaskTimeline retrieves every status change of a client's task, oldest first
+	GetTaskTimeline(ctx context.Context, clientID string, taskID int) ([]TaskStatusDTO, error)
+
+	// SearchTasks retrieves one page of a client's active tasks whose name, email or address
+	// match a search text, most relevant first
+	SearchTasks(ctx context.Context, clientName string, clientID string, text string, page SearchPage) ([]TaskSearchResult, error)
+
+	// GetGroupReport aggregates headcount, salary and age of a client's active tasks per
+	// department or position, ordered by group
+	GetGroupReport(ctx context.Context, clientName string, clientID string, groupBy string) ([]GroupReportDTO, error)
+
+	// GetHireReport counts a client's active tasks by hire month or year, oldest first
+	GetHireReport(ctx context.Context, clientName string, clientID string, period string) ([]HireCountDTO, error)
+
+	// GetStatusDurations summarises how long a client's tasks stayed in each status before
+	// their next status change, ordered by status
+	GetStatusDurations(ctx context.Context, clientName string, clientID string) ([]StatusDurationDTO, error)
+
+	// GetCompletionThroughput counts the tasks a client completed in each of the last weeks,
+	// oldest first, including weeks without completions
+	GetCompletionThroughput(ctx context.Context, clientName string, clientID string, weeks int) ([]WeeklyCountDTO, error)
+
+	// CountStuckTasks counts a client's active tasks that have been IN_PROGRESS for longer
+	// than stuckAfter
+	CountStuckTasks(ctx context.Context, clientName string, clientID string, stuckAfter time.Duration) (int, error)
+}
+
+// ErrTaskNotFound is returned when a task does not exist for the requesting client
+var ErrTaskNotFound = errors.New("task not found")
+
+// Sort fields accepted by GetActiveTasks; SortByID is used when none is requested
+const (
+	SortByID        = "id"
+	SortByName      = "name"
+	SortByHireDate  = "hire_date"
+	SortBySalary    = "salary"
+	SortByCreatedAt = "created_at"
+)
+
+// Fields GetGroupReport can group tasks by
+const (
+	GroupByDepartment = "department"
+	GroupByPosition   = "position"
+)
+
+// Periods GetHireReport can count hires by
+const (
+	PeriodMonth = "month"
+	PeriodYear  = "year"
+)
+
+// InitialStatus is the current status of a task that has no status history
+const InitialStatus = "PENDING"
+
+// TaskFilter narrows the active tasks returned for a client
+type TaskFilter struct {
+	// Status keeps only tasks whose current status matches; empty keeps every task
+	Status string
+	// Department and Position keep tasks with a matching value, ignoring case
+	Department string
+	Position   string
+	// HiredFrom, HiredTo, MinSalary and MaxSalary are inclusive bounds; nil means unbounded
+	HiredFrom *time.Time
+	HiredTo   *time.Time
+	MinSalary *float64
+	MaxSalary *float64
+}
+
+// TaskPage selects a page of tasks in a stable order. Tasks are ordered by SortBy and
+// then by ID, both in the same direction.
+type TaskPage struct {
+	Limit      int
+	SortBy     string
+	Descending bool
+	// After continues from the last task of the previous page; nil starts from the top
+	After *TaskCursor
+}
+
+// TaskCursor identifies the last task of a page by its sort value and ID
+type TaskCursor struct {
+	// SortValue is the task's SortBy field formatted as by FormatSortValue
+	SortValue string
+	ID        int
+}
+
+// Layouts of the date and timestamp sort values of a TaskCursor
+const (
+	sortDateLayout      = "2006-01-02"
+	sortTimestampLayout = "2006-01-02 15:04:05.999999"
+)
+
+// FormatSortValue renders the field a task is sorted by in the form TaskCursor expects
+func FormatSortValue(task TaskDTO, sortBy string) string {
+	switch sortBy {
+	case SortByName:
+		return task.Name
+	case SortByHireDate:
+		return task.HireDate.Format(sortDateLayout)
+	case SortBySalary:
+		return strconv.FormatFloat(task.Salary, 'f', -1, 64)
+	case SortByCreatedAt:
+		return task.CreatedAt.Format(sortTimestampLayout)
+	default:
+		return strconv.Itoa(task.ID)
+	}
+}
+
+// ValidSortValue reports whether value is in the form FormatSortValue renders for sortBy,
+// so that it can be cast to the type of the sort column
+func ValidSortValue(sortBy, value string) bool {
+	switch sortBy {
+	case SortByName:
+		return utf8.ValidString(value) && !strings.ContainsRune(value, 0)
+	case SortByHireDate:
+		date, err := time.Parse(sortDateLayout, value)
+		return err == nil && date.Format(sortDateLayout) == value
+	case SortBySalary:
+		salary, err := strconv.ParseFloat(value, 64)
+		return err == nil && !math.IsNaN(salary) && !math.IsInf(salary, 0) &&
+			strconv.FormatFloat(salary, 'f', -1, 64) == value
+	case SortByCreatedAt:
+		createdAt, err := time.Parse(sortTimestampLayout, value)
+		return err == nil && createdAt.Format(sortTimestampLayout) == value
+	default:
+		id, err := strconv.ParseInt(value, 10, 32)
+		return err == nil && strconv.FormatInt(id, 10) == value
+	}
+}
+
+// HistoryPage selects a page of status history, newest first
+type HistoryPage struct {
+	Limit int
+	// After continues from the last entry of the previous page; nil starts from the newest
+	After *HistoryCursor
+}
+
+// HistoryCursor identifies the last status entry of a page
+type HistoryCursor struct {
+	CreatedAt time.Time
+	ID        int
+}
+
+// SearchPage selects a page of search results, ordered by descending rank and then by ID
+type SearchPage struct {
+	Limit int
+	// After continues from the last result of the previous page; nil starts from the best match
+	After *SearchCursor
+}
+
+// SearchCursor identifies the last search result of a page
+type SearchCursor struct {
+	Rank float64
+	ID   int
+}
+
+// TaskSearchResult is a task matching a search with its relevance; higher ranks match better
+type TaskSearchResult struct {
+	TaskDTO
+	Rank float64 `json:"rank"`
 }
 
 // TaskDTO represents a task query result
 type TaskDTO struct {
-	ID          int     `json:"id"`
-	Name        string  `json:"name"`
-	Email       string  `json:"email"`
-	Age         int     `json:"age"`
-	Address     string  `json:"address"`
-	PhoneNumber string  `json:"phone_number"`
-	Department  string  `json:"department"`
-	Position    string  `json:"position"`
-	Salary      float64 `json:"salary"`
-	ClientName  string  `json:"client_name"`
-	ClientID    string  `json:"client_id"`
-	IsActive    bool    `json:"is_active"`
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Age         int       `json:"age"`
+	Address     string    `json:"address"`
+	PhoneNumber string    `json:"phone_number"`
+	Department  string    `json:"department"`
+	Position    string    `json:"position"`
+	Salary      float64   `json:"salary"`
+	ClientName  string    `json:"client_name"`
+	ClientID    string    `json:"client_id"`
+	IsActive    bool      `json:"is_active"`
+	HireDate    time.Time `json:"hire_date"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+	// CurrentStatus is the latest status of the task, or InitialStatus without history
+	CurrentStatus string `json:"current_status"`
+	// StatusDescription and StatusUpdatedAt describe the latest status row, if any
+	StatusDescription string     `json:"status_description"`
+	StatusUpdatedAt   *time.Time `json:"status_updated_at"`
 }
 
 // TaskStatusDTO represents a task status history entry
 type TaskStatusDTO struct {
+	ID                int       `json:"id"`
 	TaskID            int       `json:"task_id"`
 	ClientName        string    `json:"client_name"`
 	ClientID          string    `json:"client_id"`
@@ -40,3 +220,38 @@ type TaskStatusDTO struct {
 	UpdatedBy         string    `json:"updated_by"`
 	CreatedAt         time.Time `json:"created_at"`
 }
+
+// GroupReportDTO aggregates the active tasks of one department or position. Averages are
+// rounded to two decimals.
+type GroupReportDTO struct {
+	Group         string  `json:"group"`
+	Headcount     int     `json:"headcount"`
+	AverageSalary float64 `json:"average_salary"`
+	MinSalary     float64 `json:"min_salary"`
+	MaxSalary     float64 `json:"max_salary"`
+	TotalSalary   float64 `json:"total_salary"`
+	AverageAge    float64 `json:"average_age"`
+}
+
+// HireCountDTO counts the active tasks hired in the period starting at Period
+type HireCountDTO struct {
+	Period time.Time `json:"period"`
+	Hires  int       `json:"hires"`
+}
+
+// StatusDurationDTO summarises the time tasks spent in a status, in seconds. Only stays
+// that ended with a later status change are counted.
+type StatusDurationDTO struct {
+	Status         string  `json:"status"`
+	Count          int     `json:"count"`
+	AverageSeconds float64 `json:"average_seconds"`
+	MedianSeconds  float64 `json:"median_seconds"`
+	P90Seconds     float64 `json:"p90_seconds"`
+	P95Seconds     float64 `json:"p95_seconds"`
+}
+
+// WeeklyCountDTO counts the tasks completed in the week starting at WeekStart
+type WeeklyCountDTO struct {
+	WeekStart time.Time `json:"week_start"`
+	Completed int       `json:"completed"`
+}
@@ -1,40 +1,87 @@
 package AuthRequest
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
 	"net/http"
 	"taskmanager/RequestControllers/AuthRequest/dto"
 	"taskmanager/RequestControllers/AuthRequest/interfaces"
 	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/RequestControllers/httpSetup/middleware"
+	clientInterfaces "taskmanager/Services/CommandServices/ClientService/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	tokenInterfaces "taskmanager/Services/CommandServices/TokenService/interfaces"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// adminKeyHeader carries the administration key, accepted on the client administration
+	// routes in place of a token with the admin scope
+	adminKeyHeader = "X-Admin-Key"
+	// jwksMaxAge is how long verifiers may cache the key set, in seconds. Publish a new key
+	// at least this long before signing with it.
+	jwksMaxAge = 300
+)
+
 type authController struct {
-	jwtManager *jwt.JWTManager
-	logger     *logrus.Logger
+	jwtManager    *jwt.JWTManager
+	clientService clientInterfaces.ClientService
+	tokenService  tokenInterfaces.TokenService
+	// authenticate validates the bearer token of the client administration routes
+	authenticate gin.HandlerFunc
+	// adminKey authorises the client administration routes without a token; empty
+	// disables it
+	adminKey string
+	logger   *logrus.Logger
 }
 
-func NewAuthController(jwtManager *jwt.JWTManager, logger *logrus.Logger) interfaces.AuthController {
+func NewAuthController(
+	jwtManager *jwt.JWTManager,
+	clientService clientInterfaces.ClientService,
+	tokenService tokenInterfaces.TokenService,
+	authenticate gin.HandlerFunc,
+	adminKey string,
+	logger *logrus.Logger,
+) interfaces.AuthController {
 	return &authController{
-		jwtManager: jwtManager,
-		logger:     logger,
+		jwtManager:    jwtManager,
+		clientService: clientService,
+		tokenService:  tokenService,
+		authenticate:  authenticate,
+		adminKey:      adminKey,
+		logger:        logger,
 	}
 }
 
 func (c *authController) RegisterRoutes(router *gin.RouterGroup) {
 	router.POST("/token", c.GenerateToken)
+	router.POST("/refresh", c.RefreshToken)
+	router.POST("/revoke", c.RevokeToken)
+
+	admin := middleware.RequireScopes(jwt.ScopeAdmin)
+
+	clients := router.Group("/clients", c.authenticateAdmin)
+	clients.POST("", admin, c.RegisterClient)
+	clients.POST("/:id/rotate", admin, c.RotateClientSecret)
+	clients.PUT("/:id/scopes", admin, c.UpdateClientScopes)
+	clients.DELETE("/:id", admin, c.DisableClient)
 }
 
 // GenerateToken godoc
 // @Summary Generate JWT token
-// @Description Generates a JWT token for client authentication
+// @Description Generates a short-lived JWT access token and a refresh token for a registered, active client whose ID, name and secret match
 // @Tags auth
 // @Accept json
 // @Produce json
 // @Param request body dto.GenerateTokenRequest true "Client credentials"
 // @Success 200 {object} dto.GenerateTokenResponse
 // @Failure 400 {object} dto.GenerateTokenResponse
+// @Failure 401 {object} dto.GenerateTokenResponse "Invalid client credentials"
 // @Router /api/auth/token [post]
 func (c *authController) GenerateToken(ctx *gin.Context) {
 	var request dto.GenerateTokenRequest
@@ -47,7 +94,24 @@ func (c *authController) GenerateToken(ctx *gin.Context) {
 		return
 	}
 
-	token, err := c.jwtManager.GenerateToken(request.ClientName, request.ClientID)
+	claims, err := c.clientService.Authenticate(ctx.Request.Context(), request.ClientName, request.ClientID, request.ClientSecret)
+	if errors.Is(err, clientInterfaces.ErrInvalidCredentials) {
+		ctx.JSON(http.StatusUnauthorized, dto.GenerateTokenResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to check client credentials")
+		ctx.JSON(http.StatusInternalServerError, dto.GenerateTokenResponse{
+			Success: false,
+			Message: "Failed to generate token",
+		})
+		return
+	}
+
+	pair, err := c.tokenService.IssueTokens(ctx.Request.Context(), claims)
 	if err != nil {
 		c.logger.WithError(err).Error("Failed to generate token")
 		ctx.JSON(http.StatusInternalServerError, dto.GenerateTokenResponse{
@@ -57,9 +121,296 @@ func (c *authController) GenerateToken(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, dto.GenerateTokenResponse{
+	ctx.JSON(http.StatusOK, tokenResponse(pair, "Token generated successfully"))
+}
+
+// RefreshToken godoc
+// @Summary Refresh JWT token
+// @Description Exchanges a refresh token for a new access token and refresh token. Each refresh token can be used once; reusing one revokes every token issued from it.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshTokenRequest true "Refresh token"
+// @Success 200 {object} dto.GenerateTokenResponse
+// @Failure 400 {object} dto.GenerateTokenResponse
+// @Failure 401 {object} dto.GenerateTokenResponse "Unknown, expired, revoked or reused refresh token"
+// @Router /api/auth/refresh [post]
+func (c *authController) RefreshToken(ctx *gin.Context) {
+	var request dto.RefreshTokenRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.GenerateTokenResponse{
+			Success: false,
+			Message: "Invalid request format",
+		})
+		return
+	}
+
+	pair, err := c.tokenService.Refresh(ctx.Request.Context(), request.RefreshToken)
+	if errors.Is(err, tokenInterfaces.ErrInvalidRefreshToken) {
+		ctx.JSON(http.StatusUnauthorized, dto.GenerateTokenResponse{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to refresh token")
+		ctx.JSON(http.StatusInternalServerError, dto.GenerateTokenResponse{
+			Success: false,
+			Message: "Failed to refresh token",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, tokenResponse(pair, "Token refreshed successfully"))
+}
+
+// RevokeToken godoc
+// @Summary Revoke a token
+// @Description Revokes an access token until it expires, or a refresh token together with every token issued from it. Unknown and expired tokens are accepted so that the response does not reveal which tokens exist.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RevokeTokenRequest true "Token to revoke"
+// @Success 200 {object} dto.RevokeTokenResponse
+// @Failure 400 {object} dto.RevokeTokenResponse
+// @Router /api/auth/revoke [post]
+func (c *authController) RevokeToken(ctx *gin.Context) {
+	var request dto.RevokeTokenRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.RevokeTokenResponse{
+			Success: false,
+			Message: "Invalid request format",
+		})
+		return
+	}
+
+	if err := c.tokenService.Revoke(ctx.Request.Context(), request.Token); err != nil {
+		c.logger.WithError(err).Error("Failed to revoke token")
+		ctx.JSON(http.StatusInternalServerError, dto.RevokeTokenResponse{
+			Success: false,
+			Message: "Failed to revoke token",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, dto.RevokeTokenResponse{
 		Success: true,
-		Token:   token,
-		Message: "Token generated successfully",
+		Message: "Token revoked successfully",
+	})
+}
+
+// JWKS godoc
+// @Summary Get the token verification keys
+// @Description Returns the public keys access tokens are signed with as a JSON Web Key Set, so that other services can verify tokens. A token names its key in the kid header.
+// @Tags auth
+// @Produce json
+// @Success 200 {object} jwt.JWKS
+// @Router /.well-known/jwks.json [get]
+func (c *authController) JWKS(ctx *gin.Context) {
+	ctx.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", jwksMaxAge))
+	ctx.JSON(http.StatusOK, c.jwtManager.JWKS())
+}
+
+// RegisterClient godoc
+// @Summary Register a client
+// @Description Registers a client and returns its API secret; the secret is only shown once. Pass client_id to register an existing tenant, and scopes to grant other scopes than tasks:read, tasks:write and import:run.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param X-Admin-Key header string false "Administration key, in place of a token with the admin scope"
+// @Param request body schemas.RegisterClientRequestDTO true "Client"
+// @Success 201 {object} schemas.ClientResponseDTO
+// @Failure 400 {object} schemas.ClientResponseDTO
+// @Failure 403 {object} schemas.ClientResponseDTO "Missing admin scope or wrong administration key"
+// @Failure 409 {object} schemas.ClientResponseDTO "Client ID or name already registered"
+// @Router /api/auth/clients [post]
+func (c *authController) RegisterClient(ctx *gin.Context) {
+	var request schemas.RegisterClientRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, schemas.ClientResponseDTO{
+			Success: false,
+			Message: "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := c.clientService.RegisterClient(ctx.Request.Context(), request)
+	if err != nil {
+		c.respondClientError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, response)
+}
+
+// RotateClientSecret godoc
+// @Summary Rotate a client secret
+// @Description Issues a new API secret for a client; the old secret stops working immediately and the new one is only shown once
+// @Tags auth
+// @Produce json
+// @Security Bearer
+// @Param X-Admin-Key header string false "Administration key, in place of a token with the admin scope"
+// @Param id path string true "Client ID"
+// @Success 200 {object} schemas.ClientResponseDTO
+// @Failure 403 {object} schemas.ClientResponseDTO "Missing admin scope or wrong administration key"
+// @Failure 404 {object} schemas.ClientResponseDTO "Client not found"
+// @Router /api/auth/clients/{id}/rotate [post]
+func (c *authController) RotateClientSecret(ctx *gin.Context) {
+	clientID, ok := c.clientID(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.clientService.RotateSecret(ctx.Request.Context(), clientID)
+	if err != nil {
+		c.respondClientError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// UpdateClientScopes godoc
+// @Summary Change the scopes of a client
+// @Description Replaces the scopes granted to a client. Access tokens already issued keep their scopes until they are refreshed.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param X-Admin-Key header string false "Administration key, in place of a token with the admin scope"
+// @Param id path string true "Client ID"
+// @Param request body schemas.UpdateClientScopesRequestDTO true "Scopes"
+// @Success 200 {object} schemas.ClientResponseDTO
+// @Failure 400 {object} schemas.ClientResponseDTO "Unknown scope"
+// @Failure 403 {object} schemas.ClientResponseDTO "Missing admin scope or wrong administration key"
+// @Failure 404 {object} schemas.ClientResponseDTO "Client not found"
+// @Router /api/auth/clients/{id}/scopes [put]
+func (c *authController) UpdateClientScopes(ctx *gin.Context) {
+	clientID, ok := c.clientID(ctx)
+	if !ok {
+		return
+	}
+
+	var request schemas.UpdateClientScopesRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, schemas.ClientResponseDTO{
+			Success: false,
+			Message: "Invalid request format: " + err.Error(),
+		})
+		return
+	}
+
+	response, err := c.clientService.UpdateScopes(ctx.Request.Context(), clientID, request.Scopes)
+	if err != nil {
+		c.respondClientError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// DisableClient godoc
+// @Summary Disable a client
+// @Description Stops a client from requesting tokens
+// @Tags auth
+// @Produce json
+// @Security Bearer
+// @Param X-Admin-Key header string false "Administration key, in place of a token with the admin scope"
+// @Param id path string true "Client ID"
+// @Success 200 {object} schemas.ClientResponseDTO
+// @Failure 403 {object} schemas.ClientResponseDTO "Missing admin scope or wrong administration key"
+// @Failure 404 {object} schemas.ClientResponseDTO "Client not found"
+// @Router /api/auth/clients/{id} [delete]
+func (c *authController) DisableClient(ctx *gin.Context) {
+	clientID, ok := c.clientID(ctx)
+	if !ok {
+		return
+	}
+
+	response, err := c.clientService.DisableClient(ctx.Request.Context(), clientID)
+	if err != nil {
+		c.respondClientError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// authenticateAdmin authenticates a client administration request with its bearer token,
+// or with the administration key when the request carries one. The key grants the admin
+// scope, so that clients can be managed before any client holds it.
+func (c *authController) authenticateAdmin(ctx *gin.Context) {
+	if ctx.GetHeader(adminKeyHeader) == "" {
+		c.authenticate(ctx)
+		return
+	}
+
+	if c.adminKey == "" {
+		ctx.AbortWithStatusJSON(http.StatusForbidden, schemas.ClientResponseDTO{
+			Success: false,
+			Message: "Administration key is not configured",
+		})
+		return
+	}
+
+	// Hash both keys so the comparison takes the same time whatever their lengths
+	given := sha256.Sum256([]byte(ctx.GetHeader(adminKeyHeader)))
+	expected := sha256.Sum256([]byte(c.adminKey))
+	if subtle.ConstantTimeCompare(given[:], expected[:]) != 1 {
+		c.logger.WithField("client_ip", ctx.ClientIP()).Warn("Client administration refused")
+		ctx.AbortWithStatusJSON(http.StatusForbidden, schemas.ClientResponseDTO{
+			Success: false,
+			Message: "Invalid administration key",
+		})
+		return
+	}
+
+	ctx.Set("scopes", []string{jwt.ScopeAdmin})
+	ctx.Next()
+}
+
+// clientID reads the client ID path parameter, answering 404 if it is not a UUID
+func (c *authController) clientID(ctx *gin.Context) (string, bool) {
+	clientID := ctx.Param("id")
+	if _, err := uuid.Parse(clientID); err != nil {
+		ctx.JSON(http.StatusNotFound, schemas.ClientResponseDTO{
+			Success: false,
+			Message: clientInterfaces.ErrClientNotFound.Error(),
+		})
+		return "", false
+	}
+	return clientID, true
+}
+
+// respondClientError maps client service errors to HTTP statuses
+func (c *authController) respondClientError(ctx *gin.Context, err error) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, clientInterfaces.ErrInvalidClient):
+		status = http.StatusBadRequest
+	case errors.Is(err, clientInterfaces.ErrClientNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, clientInterfaces.ErrDuplicateClient):
+		status = http.StatusConflict
+	default:
+		c.logger.WithError(err).Error("Client command failed")
+	}
+
+	ctx.JSON(status, schemas.ClientResponseDTO{
+		Success: false,
+		Message: err.Error(),
 	})
 }
+
+// tokenResponse builds the response carrying a newly issued token pair
+func tokenResponse(pair *schemas.TokenPairDTO, message string) dto.GenerateTokenResponse {
+	return dto.GenerateTokenResponse{
+		Success:      true,
+		Token:        pair.AccessToken,
+		RefreshToken: pair.RefreshToken,
+		ExpiresIn:    pair.ExpiresIn,
+		Message:      message,
+	}
+}
@@ -6,14 +6,40 @@ type GenerateTokenRequest struct {
 	ClientName string `json:"client_name" binding:"required" example:"Client One Corp"`
 	// Client UUID for authentication
 	ClientID string `json:"client_id" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"`
+	// Client API secret issued at registration or rotation
+	ClientSecret string `json:"client_secret" binding:"required" example:"2Zq0...w8"`
 }
 
 // GenerateTokenResponse represents the token generation response
 type GenerateTokenResponse struct {
 	// Indicates if the operation was successful
 	Success bool `json:"success" example:"true"`
-	// JWT token if successful
+	// JWT access token if successful
 	Token string `json:"token,omitempty" example:"eyJhbGciOiJIUzI1NiIs..."`
+	// Single-use token that renews the access token at /api/auth/refresh
+	RefreshToken string `json:"refresh_token,omitempty" example:"pC9tYk3...Qw"`
+	// Lifetime of the access token in seconds
+	ExpiresIn int64 `json:"expires_in,omitempty" example:"900"`
 	// Response message
 	Message string `json:"message,omitempty" example:"Token generated successfully"`
 }
+
+// RefreshTokenRequest represents the token refresh request
+type RefreshTokenRequest struct {
+	// Refresh token returned with the last access token
+	RefreshToken string `json:"refresh_token" binding:"required" example:"pC9tYk3...Qw"`
+}
+
+// RevokeTokenRequest represents the token revocation request
+type RevokeTokenRequest struct {
+	// Access token or refresh token to revoke
+	Token string `json:"token" binding:"required" example:"eyJhbGciOiJIUzI1NiIs..."`
+}
+
+// RevokeTokenResponse represents the token revocation response
+type RevokeTokenResponse struct {
+	// Indicates if the operation was successful
+	Success bool `json:"success" example:"true"`
+	// Response message
+	Message string `json:"message,omitempty" example:"Token revoked successfully"`
+}
@@ -5,4 +5,11 @@ import "github.com/gin-gonic/gin"
 type AuthController interface {
 	RegisterRoutes(router *gin.RouterGroup)
 	GenerateToken(c *gin.Context)
+	RefreshToken(c *gin.Context)
+	RevokeToken(c *gin.Context)
+	JWKS(c *gin.Context)
+	RegisterClient(c *gin.Context)
+	RotateClientSecret(c *gin.Context)
+	UpdateClientScopes(c *gin.Context)
+	DisableClient(c *gin.Context)
 }
@@ -2,54 +2,108 @@
 package CommandRequest
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/RequestControllers/httpSetup/middleware"
+	jobInterfaces "taskmanager/Services/CommandServices/ImportJobService/interfaces"
 	"taskmanager/Services/CommandServices/ImportTaskService/interfaces"
 	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	taskInterfaces "taskmanager/Services/CommandServices/TaskCommandService/interfaces"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
 )
 
+// errUploadTooLarge is returned when a request body is larger than the upload limit
+var errUploadTooLarge = errors.New("upload too large")
+
 type commandApiController struct {
 	importService interfaces.ImportService
-	logger        *logrus.Logger
+	jobService    jobInterfaces.ImportJobService
+	taskService   taskInterfaces.TaskCommandService
+	// maxUploadBytes is the largest request body the upload endpoints read
+	maxUploadBytes int64
+	logger         *logrus.Logger
 }
 
 func NewCommandApiController(
 	importService interfaces.ImportService,
+	jobService jobInterfaces.ImportJobService,
+	taskService taskInterfaces.TaskCommandService,
+	maxUploadBytes int64,
 	logger *logrus.Logger,
 ) *commandApiController {
 	return &commandApiController{
-		importService: importService,
-		logger:        logger,
+		importService:  importService,
+		jobService:     jobService,
+		taskService:    taskService,
+		maxUploadBytes: maxUploadBytes,
+		logger:         logger,
 	}
 }
 
 func (c *commandApiController) RegisterRoutes(router *gin.RouterGroup) {
-	router.POST("/import", c.ImportTasks)
+	importRun := middleware.RequireScopes(jwt.ScopeImportRun)
+	tasksWrite := middleware.RequireScopes(jwt.ScopeTasksWrite)
+
+	router.POST("/import", importRun, c.ImportTasks)
+	router.POST("/import/upload", importRun, c.UploadTasks)
+	router.POST("/import/validate", importRun, c.ValidateTasks)
+	router.POST("/import/jobs", importRun, c.SubmitImportJob)
+	router.GET("/import/jobs/:id", importRun, c.GetImportJob)
+	router.DELETE("/import/jobs/:id", importRun, c.CancelImportJob)
+	router.GET("/import/history", importRun, c.ImportHistory)
+	router.POST("/tasks", tasksWrite, c.CreateTask)
+	router.PUT("/tasks/:id", tasksWrite, c.UpdateTask)
+	router.PATCH("/tasks/:id", tasksWrite, c.PatchTask)
+	router.DELETE("/tasks/:id", tasksWrite, c.DeleteTask)
+	router.POST("/tasks/:id/status", tasksWrite, c.ChangeTaskStatus)
 }
 
 // ImportTasks godoc
-// @Summary Import tasks from CSV
-// @Description Triggers the task import process from a specified directory
+// @Summary Import tasks from the import directory
+// @Description Imports every CSV, JSON, NDJSON and XLSX file in the import directory for the authenticated client
 // @Tags commands
 // @Accept json
 // @Produce json
+// @Security Bearer
+// @Param mode query string false "Import mode: strict (default) rejects the whole file on any invalid row, partial inserts the valid rows" Enums(strict, partial)
+// @Param profile query string false "Column mapping profile; defaults to the configured default profile"
+// @Param sheet query string false "XLSX worksheet name or 1-based index; defaults to the first sheet"
+// @Param upsert query bool false "Update the existing task with the same email instead of inserting a duplicate"
+// @Param force query bool false "Import a file even if the same content was already imported successfully"
 // @Success 200 {object} schemas.TaskImportResponse "Successful import response"
+// @Failure 400 {object} schemas.TaskImportResponse "Invalid import options or unknown profile"
+// @Failure 401 {object} schemas.TaskImportResponse "Unauthorized"
 // @Failure 500 {object} schemas.TaskImportResponse "Error import response"
 // @Router /api/commands/import [post]
 func (c *commandApiController) ImportTasks(ctx *gin.Context) {
 	c.logger.Info("Received request to import tasks")
 
-	response, err := c.importService.Import()
+	opts, err := importOptions(ctx)
+	if err != nil {
+		c.logger.WithError(err).Error("Invalid import options")
+		ctx.JSON(http.StatusBadRequest, schemas.ImportTaskResponseDTO{
+			Success: false,
+			Message: "Invalid import options",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	response, err := c.importService.Import(ctx.Request.Context(), clientClaims(ctx), opts)
 	if err != nil {
 		c.logger.WithError(err).Error("Failed to import tasks")
 		if response != nil {
 			// Use the structured response even in error case
-			ctx.JSON(http.StatusInternalServerError, response)
+			ctx.JSON(importErrorStatus(err), response)
 		} else {
 			// Fallback if no response was received
-			ctx.JSON(http.StatusInternalServerError, schemas.TaskImportResponse{
+			ctx.JSON(importErrorStatus(err), schemas.TaskImportResponse{
 				Success: false,
 				Message: "Failed to import tasks",
 				Errors:  []string{err.Error()},
@@ -60,3 +114,465 @@ func (c *commandApiController) ImportTasks(ctx *gin.Context) {
 
 	ctx.JSON(http.StatusOK, response)
 }
+
+// UploadTasks godoc
+// @Summary Import tasks from an uploaded file
+// @Description Imports tasks from a CSV, JSON array, NDJSON or XLSX file sent as a multipart "file" field or as a raw body. The format of a multipart file is detected from its extension.
+// @Tags commands
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Accept json
+// @Accept application/x-ndjson
+// @Accept application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce json
+// @Security Bearer
+// @Param file formData file false "CSV, JSON, NDJSON or XLSX file to import"
+// @Param mode query string false "Import mode: strict (default) or partial" Enums(strict, partial)
+// @Param profile query string false "Column mapping profile; defaults to the configured default profile"
+// @Param sheet query string false "XLSX worksheet name or 1-based index; defaults to the first sheet"
+// @Param upsert query bool false "Update the existing task with the same email instead of inserting a duplicate"
+// @Param force query bool false "Import a file even if the same content was already imported successfully"
+// @Success 200 {object} schemas.ImportTaskResponseDTO "Successful import response"
+// @Failure 400 {object} schemas.ImportTaskResponseDTO "Missing or unsupported upload, or unknown profile"
+// @Failure 401 {object} schemas.ImportTaskResponseDTO "Unauthorized"
+// @Failure 409 {object} schemas.ImportTaskResponseDTO "File already imported; retry with force=true"
+// @Failure 413 {object} schemas.ImportTaskResponseDTO "File larger than the upload limit"
+// @Failure 500 {object} schemas.ImportTaskResponseDTO "Error import response"
+// @Router /api/commands/import/upload [post]
+func (c *commandApiController) UploadTasks(ctx *gin.Context) {
+	c.logger.Info("Received request to import uploaded tasks")
+
+	opts, err := importOptions(ctx)
+	if err != nil {
+		c.logger.WithError(err).Error("Invalid import options")
+		ctx.JSON(http.StatusBadRequest, schemas.ImportTaskResponseDTO{
+			Success: false,
+			Message: "Invalid import options",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	fileName, format, reader, err := c.uploadedFile(ctx)
+	if err != nil {
+		c.logger.WithError(err).Error("Invalid upload request")
+		ctx.JSON(uploadErrorStatus(err), schemas.ImportTaskResponseDTO{
+			Success: false,
+			Message: "Invalid upload request",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	defer reader.Close()
+	opts.Format = format
+
+	response, err := c.importService.ImportFromReader(
+		ctx.Request.Context(),
+		clientClaims(ctx),
+		fileName,
+		reader,
+		opts,
+	)
+	if uploadTooLarge(ctx) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, schemas.ImportTaskResponseDTO{
+			Success: false,
+			Message: c.uploadLimitError().Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to import uploaded tasks")
+		if response != nil {
+			ctx.JSON(importErrorStatus(err), response)
+		} else {
+			ctx.JSON(importErrorStatus(err), schemas.ImportTaskResponseDTO{
+				Success: false,
+				Message: "Failed to import tasks",
+				Errors:  []string{err.Error()},
+			})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// ValidateTasks godoc
+// @Summary Validate an import file without importing it
+// @Description Runs every import check over a CSV, JSON array, NDJSON or XLSX file sent as a multipart "file" field or raw body and reports all problems found. Nothing is stored.
+// @Tags commands
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Accept json
+// @Accept application/x-ndjson
+// @Accept application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce json
+// @Security Bearer
+// @Param file formData file false "CSV, JSON, NDJSON or XLSX file to validate"
+// @Param profile query string false "Column mapping profile; defaults to the configured default profile"
+// @Param sheet query string false "XLSX worksheet name or 1-based index; defaults to the first sheet"
+// @Success 200 {object} schemas.ImportValidationResponseDTO "Validation report"
+// @Failure 400 {object} schemas.ImportValidationResponseDTO "Missing or unsupported upload"
+// @Failure 401 {object} schemas.ImportValidationResponseDTO "Unauthorized"
+// @Failure 413 {object} schemas.ImportValidationResponseDTO "File larger than the upload limit"
+// @Failure 500 {object} schemas.ImportValidationResponseDTO "Error validating file"
+// @Router /api/commands/import/validate [post]
+func (c *commandApiController) ValidateTasks(ctx *gin.Context) {
+	c.logger.Info("Received request to validate tasks")
+
+	opts, err := importOptions(ctx)
+	if err != nil {
+		c.logger.WithError(err).Error("Invalid import options")
+		ctx.JSON(http.StatusBadRequest, schemas.ImportValidationResponseDTO{
+			Message: "Invalid import options",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+
+	fileName, format, reader, err := c.uploadedFile(ctx)
+	if err != nil {
+		c.logger.WithError(err).Error("Invalid upload request")
+		ctx.JSON(uploadErrorStatus(err), schemas.ImportValidationResponseDTO{
+			Message: "Invalid upload request",
+			Errors:  []string{err.Error()},
+		})
+		return
+	}
+	defer reader.Close()
+	opts.Format = format
+
+	response, err := c.importService.ValidateFromReader(ctx.Request.Context(), clientClaims(ctx), fileName, reader, opts)
+	if uploadTooLarge(ctx) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, schemas.ImportValidationResponseDTO{
+			FileName: fileName,
+			Message:  c.uploadLimitError().Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to validate tasks")
+		ctx.JSON(importErrorStatus(err), schemas.ImportValidationResponseDTO{
+			FileName: fileName,
+			Message:  "Failed to validate tasks",
+			Errors:   []string{err.Error()},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// SubmitImportJob godoc
+// @Summary Submit an asynchronous import job
+// @Description Queues an import of the import directory, or of a CSV, JSON array, NDJSON or XLSX file sent as a multipart "file" field or raw body, and returns the job immediately
+// @Tags commands
+// @Accept multipart/form-data
+// @Accept text/csv
+// @Accept json
+// @Accept application/x-ndjson
+// @Accept application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce json
+// @Security Bearer
+// @Param file formData file false "CSV, JSON, NDJSON or XLSX file to import"
+// @Param mode query string false "Import mode: strict (default) or partial" Enums(strict, partial)
+// @Param profile query string false "Column mapping profile; defaults to the configured default profile"
+// @Param sheet query string false "XLSX worksheet name or 1-based index; defaults to the first sheet"
+// @Param upsert query bool false "Update the existing task with the same email instead of inserting a duplicate"
+// @Param force query bool false "Import a file even if the same content was already imported successfully"
+// @Success 202 {object} schemas.ImportJobResponseDTO "Job queued"
+// @Failure 400 {object} schemas.ImportJobResponseDTO "Invalid upload"
+// @Failure 401 {object} schemas.ImportJobResponseDTO "Unauthorized"
+// @Failure 413 {object} schemas.ImportJobResponseDTO "File larger than the upload limit"
+// @Failure 503 {object} schemas.ImportJobResponseDTO "Job queue unavailable"
+// @Router /api/commands/import/jobs [post]
+func (c *commandApiController) SubmitImportJob(ctx *gin.Context) {
+	c.logger.Info("Received request to submit import job")
+
+	opts, err := importOptions(ctx)
+	if err != nil {
+		c.logger.WithError(err).Error("Invalid import options")
+		ctx.JSON(http.StatusBadRequest, schemas.ImportJobResponseDTO{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	var fileName string
+	var reader io.Reader
+	if ctx.Request.ContentLength != 0 && ctx.ContentType() != "" {
+		name, format, upload, err := c.uploadedFile(ctx)
+		if err != nil {
+			c.logger.WithError(err).Error("Invalid upload request")
+			ctx.JSON(uploadErrorStatus(err), schemas.ImportJobResponseDTO{
+				Success: false,
+				Message: err.Error(),
+			})
+			return
+		}
+		defer upload.Close()
+		fileName, reader = name, upload
+		opts.Format = format
+	}
+
+	job, err := c.jobService.Submit(ctx.Request.Context(), clientClaims(ctx), fileName, reader, opts)
+	if uploadTooLarge(ctx) {
+		ctx.JSON(http.StatusRequestEntityTooLarge, schemas.ImportJobResponseDTO{
+			Success: false,
+			Message: c.uploadLimitError().Error(),
+		})
+		return
+	}
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to submit import job")
+		c.respondJobError(ctx, err, "Failed to submit import job")
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, schemas.ImportJobResponseDTO{
+		Success: true,
+		Message: "Import job queued",
+		Job:     job,
+	})
+}
+
+// GetImportJob godoc
+// @Summary Get import job status
+// @Description Retrieves the status and progress of an import job owned by the authenticated client
+// @Tags commands
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Import job ID"
+// @Success 200 {object} schemas.ImportJobResponseDTO
+// @Failure 401 {object} schemas.ImportJobResponseDTO "Unauthorized"
+// @Failure 404 {object} schemas.ImportJobResponseDTO "Job not found"
+// @Failure 500 {object} schemas.ImportJobResponseDTO
+// @Router /api/commands/import/jobs/{id} [get]
+func (c *commandApiController) GetImportJob(ctx *gin.Context) {
+	job, err := c.jobService.GetJob(ctx.Request.Context(), clientClaims(ctx), ctx.Param("id"))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to get import job")
+		c.respondJobError(ctx, err, "Failed to retrieve import job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schemas.ImportJobResponseDTO{
+		Success: true,
+		Message: "Successfully retrieved import job",
+		Job:     job,
+	})
+}
+
+// CancelImportJob godoc
+// @Summary Cancel an import job
+// @Description Cancels a queued or running import job owned by the authenticated client
+// @Tags commands
+// @Produce json
+// @Security Bearer
+// @Param id path string true "Import job ID"
+// @Success 200 {object} schemas.ImportJobResponseDTO
+// @Failure 401 {object} schemas.ImportJobResponseDTO "Unauthorized"
+// @Failure 404 {object} schemas.ImportJobResponseDTO "Job not found"
+// @Failure 409 {object} schemas.ImportJobResponseDTO "Job already finished"
+// @Failure 500 {object} schemas.ImportJobResponseDTO
+// @Router /api/commands/import/jobs/{id} [delete]
+func (c *commandApiController) CancelImportJob(ctx *gin.Context) {
+	job, err := c.jobService.CancelJob(ctx.Request.Context(), clientClaims(ctx), ctx.Param("id"))
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to cancel import job")
+		c.respondJobError(ctx, err, "Failed to cancel import job")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schemas.ImportJobResponseDTO{
+		Success: true,
+		Message: "Import job cancellation requested",
+		Job:     job,
+	})
+}
+
+// ImportHistory godoc
+// @Summary List imported files
+// @Description Lists the files imported by the authenticated client, newest first, with their content hash, row counts and outcome
+// @Tags commands
+// @Produce json
+// @Security Bearer
+// @Param limit query int false "Maximum number of imports to return (1-500, default 50)"
+// @Success 200 {object} schemas.ImportHistoryResponseDTO
+// @Failure 400 {object} schemas.ImportHistoryResponseDTO "Invalid limit"
+// @Failure 401 {object} schemas.ImportHistoryResponseDTO "Unauthorized"
+// @Failure 500 {object} schemas.ImportHistoryResponseDTO
+// @Router /api/commands/import/history [get]
+func (c *commandApiController) ImportHistory(ctx *gin.Context) {
+	limit, err := schemas.ParseImportHistoryLimit(ctx.Query("limit"))
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, schemas.ImportHistoryResponseDTO{
+			Success: false,
+			Message: err.Error(),
+		})
+		return
+	}
+
+	response, err := c.importService.History(ctx.Request.Context(), clientClaims(ctx), limit)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to list import history")
+		ctx.JSON(http.StatusInternalServerError, schemas.ImportHistoryResponseDTO{
+			Success: false,
+			Message: "Failed to retrieve import history",
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// respondJobError maps import job errors to HTTP statuses
+func (c *commandApiController) respondJobError(ctx *gin.Context, err error, message string) {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, jobInterfaces.ErrJobNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, jobInterfaces.ErrJobFinished):
+		status = http.StatusConflict
+	case errors.Is(err, jobInterfaces.ErrQueueFull), errors.Is(err, jobInterfaces.ErrShuttingDown):
+		status = http.StatusServiceUnavailable
+	}
+
+	if status != http.StatusInternalServerError {
+		message = err.Error()
+	}
+
+	ctx.JSON(status, schemas.ImportJobResponseDTO{
+		Success: false,
+		Message: message,
+	})
+}
+
+// importErrorStatus maps import errors caused by the request to 4xx and the rest to 500
+func importErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, interfaces.ErrUnknownProfile):
+		return http.StatusBadRequest
+	case errors.Is(err, interfaces.ErrDuplicateFile):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// importOptions reads the import options from the query string
+func importOptions(ctx *gin.Context) (schemas.ImportOptions, error) {
+	mode, err := schemas.ParseImportMode(ctx.Query("mode"))
+	if err != nil {
+		return schemas.ImportOptions{}, err
+	}
+
+	upsert, err := flagQuery(ctx, "upsert")
+	if err != nil {
+		return schemas.ImportOptions{}, err
+	}
+
+	force, err := flagQuery(ctx, "force")
+	if err != nil {
+		return schemas.ImportOptions{}, err
+	}
+
+	return schemas.ImportOptions{
+		Mode:    mode,
+		Profile: ctx.Query("profile"),
+		Sheet:   ctx.Query("sheet"),
+		Upsert:  upsert,
+		Force:   force,
+	}, nil
+}
+
+// flagQuery reads an optional boolean query parameter
+func flagQuery(ctx *gin.Context, name string) (bool, error) {
+	value := ctx.Query(name)
+	if value == "" {
+		return false, nil
+	}
+
+	flag, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s flag %q: must be true or false", name, value)
+	}
+	return flag, nil
+}
+
+// uploadedFile opens the import file carried by the request, either as the multipart
+// "file" field or as a raw CSV, JSON or NDJSON body, and reports its format. The body is
+// read through a limitedBody, so a body over maxUploadBytes is never stored in full.
+func (c *commandApiController) uploadedFile(ctx *gin.Context) (string, string, io.ReadCloser, error) {
+	if ctx.Request.ContentLength > c.maxUploadBytes {
+		return "", "", nil, c.uploadLimitError()
+	}
+	body := &limitedBody{ReadCloser: http.MaxBytesReader(ctx.Writer, ctx.Request.Body, c.maxUploadBytes)}
+	ctx.Request.Body = body
+
+	if ctx.ContentType() == "multipart/form-data" {
+		header, err := ctx.FormFile("file")
+		if body.tooLarge {
+			return "", "", nil, c.uploadLimitError()
+		}
+		if err != nil {
+			return "", "", nil, fmt.Errorf("multipart field \"file\" is required: %w", err)
+		}
+		file, err := header.Open()
+		if err != nil {
+			return "", "", nil, fmt.Errorf("failed to open uploaded file: %w", err)
+		}
+		return header.Filename, schemas.ImportFormatFromFileName(header.Filename), file, nil
+	}
+
+	format, ok := schemas.ImportFormatFromContentType(ctx.ContentType())
+	if !ok {
+		return "", "", nil, fmt.Errorf("unsupported content type %q: expected multipart/form-data, text/csv, application/json, application/x-ndjson or an XLSX workbook", ctx.ContentType())
+	}
+	return "request body", format, body, nil
+}
+
+// limitedBody is a request body capped by http.MaxBytesReader. It remembers reaching the
+// cap, since the services reading it wrap or report the error in their own way.
+type limitedBody struct {
+	io.ReadCloser
+	tooLarge bool
+}
+
+func (b *limitedBody) Read(p []byte) (int, error) {
+	n, err := b.ReadCloser.Read(p)
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		b.tooLarge = true
+	}
+	return n, err
+}
+
+// uploadTooLarge reports whether reading the request body went over the upload limit
+func uploadTooLarge(ctx *gin.Context) bool {
+	body, ok := ctx.Request.Body.(*limitedBody)
+	return ok && body.tooLarge
+}
+
+// uploadLimitError describes the upload limit to a client that went over it
+func (c *commandApiController) uploadLimitError() error {
+	return fmt.Errorf("%w: files are limited to %d MB", errUploadTooLarge, c.maxUploadBytes>>20)
+}
+
+// uploadErrorStatus maps errors opening an upload to 413 when it is over the upload limit
+// and 400 otherwise
+func uploadErrorStatus(err error) int {
+	if errors.Is(err, errUploadTooLarge) {
+		return http.StatusRequestEntityTooLarge
+	}
+	return http.StatusBadRequest
+}
+
+// clientClaims builds the caller identity set on the context by the JWT middleware
+func clientClaims(ctx *gin.Context) jwt.ClientClaims {
+	return jwt.ClientClaims{
+		ClientName: ctx.GetString("client_name"),
+		ClientID:   ctx.GetString("client_id"),
+	}
+}
@@ -0,0 +1,252 @@
+// RequestControllers/CommandRequest/TaskCommands.go
+package CommandRequest
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	taskInterfaces "taskmanager/Services/CommandServices/TaskCommandService/interfaces"
+	"taskmanager/Services/CommandServices/TaskCommandService/schemas"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTask godoc
+// @Summary Create a task
+// @Description Creates an active task for the authenticated client. The task is checked with the same rules as imported rows.
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param task body schemas.TaskRequestDTO true "Task to create"
+// @Success 201 {object} schemas.TaskCommandResponseDTO
+// @Failure 400 {object} schemas.TaskCommandResponseDTO "Invalid task"
+// @Failure 401 {object} schemas.TaskCommandResponseDTO "Unauthorized"
+// @Failure 409 {object} schemas.TaskCommandResponseDTO "A task with the same email already exists"
+// @Failure 500 {object} schemas.TaskCommandResponseDTO
+// @Router /api/commands/tasks [post]
+func (c *commandApiController) CreateTask(ctx *gin.Context) {
+	var request schemas.TaskRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.respondInvalidBody(ctx, err)
+		return
+	}
+
+	task, err := c.taskService.CreateTask(ctx.Request.Context(), clientClaims(ctx), request)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to create task")
+		c.respondTaskError(ctx, err, "Failed to create task")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, schemas.TaskCommandResponseDTO{
+		Success: true,
+		Message: "Task created",
+		Task:    task,
+	})
+}
+
+// UpdateTask godoc
+// @Summary Replace a task
+// @Description Replaces every field of an active task owned by the authenticated client
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Task ID"
+// @Param task body schemas.TaskRequestDTO true "New task fields"
+// @Success 200 {object} schemas.TaskCommandResponseDTO
+// @Failure 400 {object} schemas.TaskCommandResponseDTO "Invalid task"
+// @Failure 401 {object} schemas.TaskCommandResponseDTO "Unauthorized"
+// @Failure 404 {object} schemas.TaskCommandResponseDTO "Task not found"
+// @Failure 409 {object} schemas.TaskCommandResponseDTO "A task with the same email already exists"
+// @Failure 500 {object} schemas.TaskCommandResponseDTO
+// @Router /api/commands/tasks/{id} [put]
+func (c *commandApiController) UpdateTask(ctx *gin.Context) {
+	id, ok := c.taskID(ctx)
+	if !ok {
+		return
+	}
+
+	var request schemas.TaskRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.respondInvalidBody(ctx, err)
+		return
+	}
+
+	task, err := c.taskService.UpdateTask(ctx.Request.Context(), clientClaims(ctx), id, request)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to update task")
+		c.respondTaskError(ctx, err, "Failed to update task")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schemas.TaskCommandResponseDTO{
+		Success: true,
+		Message: "Task updated",
+		Task:    task,
+	})
+}
+
+// PatchTask godoc
+// @Summary Update task fields
+// @Description Changes the fields present in the body of an active task owned by the authenticated client and keeps the rest
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Task ID"
+// @Param task body schemas.TaskPatchDTO true "Fields to change"
+// @Success 200 {object} schemas.TaskCommandResponseDTO
+// @Failure 400 {object} schemas.TaskCommandResponseDTO "Invalid task"
+// @Failure 401 {object} schemas.TaskCommandResponseDTO "Unauthorized"
+// @Failure 404 {object} schemas.TaskCommandResponseDTO "Task not found"
+// @Failure 409 {object} schemas.TaskCommandResponseDTO "A task with the same email already exists"
+// @Failure 500 {object} schemas.TaskCommandResponseDTO
+// @Router /api/commands/tasks/{id} [patch]
+func (c *commandApiController) PatchTask(ctx *gin.Context) {
+	id, ok := c.taskID(ctx)
+	if !ok {
+		return
+	}
+
+	var patch schemas.TaskPatchDTO
+	if err := ctx.ShouldBindJSON(&patch); err != nil {
+		c.respondInvalidBody(ctx, err)
+		return
+	}
+
+	task, err := c.taskService.PatchTask(ctx.Request.Context(), clientClaims(ctx), id, patch)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to patch task")
+		c.respondTaskError(ctx, err, "Failed to update task")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schemas.TaskCommandResponseDTO{
+		Success: true,
+		Message: "Task updated",
+		Task:    task,
+	})
+}
+
+// DeleteTask godoc
+// @Summary Deactivate a task
+// @Description Soft deletes an active task owned by the authenticated client by marking it inactive
+// @Tags commands
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Task ID"
+// @Success 200 {object} schemas.TaskCommandResponseDTO
+// @Failure 401 {object} schemas.TaskCommandResponseDTO "Unauthorized"
+// @Failure 404 {object} schemas.TaskCommandResponseDTO "Task not found"
+// @Failure 500 {object} schemas.TaskCommandResponseDTO
+// @Router /api/commands/tasks/{id} [delete]
+func (c *commandApiController) DeleteTask(ctx *gin.Context) {
+	id, ok := c.taskID(ctx)
+	if !ok {
+		return
+	}
+
+	if err := c.taskService.DeleteTask(ctx.Request.Context(), clientClaims(ctx), id); err != nil {
+		c.logger.WithError(err).Error("Failed to delete task")
+		c.respondTaskError(ctx, err, "Failed to delete task")
+		return
+	}
+
+	ctx.JSON(http.StatusOK, schemas.TaskCommandResponseDTO{
+		Success: true,
+		Message: "Task deactivated",
+	})
+}
+
+// ChangeTaskStatus godoc
+// @Summary Change a task's status
+// @Description Appends a status change to the history of an active task owned by the authenticated client. PENDING may move to IN_PROGRESS or CANCELLED, and IN_PROGRESS to PENDING, COMPLETED or CANCELLED. COMPLETED and CANCELLED can only be left to PENDING or IN_PROGRESS with reopen=true. A task without history is PENDING.
+// @Tags commands
+// @Accept json
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Task ID"
+// @Param status body schemas.TaskStatusRequestDTO true "New status"
+// @Success 201 {object} schemas.TaskStatusResponseDTO
+// @Failure 400 {object} schemas.TaskStatusResponseDTO "Unknown status"
+// @Failure 401 {object} schemas.TaskStatusResponseDTO "Unauthorized"
+// @Failure 404 {object} schemas.TaskStatusResponseDTO "Task not found"
+// @Failure 409 {object} schemas.TaskStatusResponseDTO "Transition not allowed, or the status changed concurrently"
+// @Failure 500 {object} schemas.TaskStatusResponseDTO
+// @Router /api/commands/tasks/{id}/status [post]
+func (c *commandApiController) ChangeTaskStatus(ctx *gin.Context) {
+	id, ok := c.taskID(ctx)
+	if !ok {
+		return
+	}
+
+	var request schemas.TaskStatusRequestDTO
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		c.respondInvalidBody(ctx, err)
+		return
+	}
+
+	status, err := c.taskService.ChangeTaskStatus(ctx.Request.Context(), clientClaims(ctx), id, request)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to change task status")
+		c.respondTaskError(ctx, err, "Failed to change task status")
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, schemas.TaskStatusResponseDTO{
+		Success: true,
+		Message: "Task status changed",
+		Status:  status,
+	})
+}
+
+// taskID reads the task ID path parameter; an ID that cannot exist is reported as not found
+func (c *commandApiController) taskID(ctx *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id <= 0 {
+		ctx.JSON(http.StatusNotFound, schemas.TaskCommandResponseDTO{
+			Success: false,
+			Message: taskInterfaces.ErrTaskNotFound.Error(),
+		})
+		return 0, false
+	}
+	return id, true
+}
+
+// respondInvalidBody reports a request body that is not a task JSON object
+func (c *commandApiController) respondInvalidBody(ctx *gin.Context, err error) {
+	c.logger.WithError(err).Error("Invalid task request body")
+	ctx.JSON(http.StatusBadRequest, schemas.TaskCommandResponseDTO{
+		Success: false,
+		Message: "Invalid request body: " + err.Error(),
+	})
+}
+
+// respondTaskError maps task command errors to HTTP statuses
+func (c *commandApiController) respondTaskError(ctx *gin.Context, err error, message string) {
+	response := schemas.TaskCommandResponseDTO{Success: false, Message: message}
+
+	var invalid *taskInterfaces.TaskValidationError
+	status := http.StatusInternalServerError
+	switch {
+	case errors.As(err, &invalid):
+		status = http.StatusBadRequest
+		response.Message = taskInterfaces.ErrInvalidTask.Error()
+		response.Errors = invalid.Fields
+	case errors.Is(err, taskInterfaces.ErrInvalidStatus):
+		status = http.StatusBadRequest
+		response.Message = err.Error()
+	case errors.Is(err, taskInterfaces.ErrTaskNotFound):
+		status = http.StatusNotFound
+		response.Message = err.Error()
+	case errors.Is(err, taskInterfaces.ErrDuplicateTask),
+		errors.Is(err, taskInterfaces.ErrIllegalTransition),
+		errors.Is(err, taskInterfaces.ErrStatusConflict):
+		status = http.StatusConflict
+		response.Message = err.Error()
+	}
+
+	ctx.JSON(status, response)
+}
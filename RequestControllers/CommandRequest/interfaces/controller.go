@@ -5,4 +5,15 @@ import "github.com/gin-gonic/gin"
 type CommandApiController interface {
     RegisterRoutes(router *gin.RouterGroup)
     ImportTasks(c *gin.Context)
+    UploadTasks(c *gin.Context)
+    ValidateTasks(c *gin.Context)
+    SubmitImportJob(c *gin.Context)
+    GetImportJob(c *gin.Context)
+    CancelImportJob(c *gin.Context)
+    ImportHistory(c *gin.Context)
+    CreateTask(c *gin.Context)
+    UpdateTask(c *gin.Context)
+    PatchTask(c *gin.Context)
+    DeleteTask(c *gin.Context)
+    ChangeTaskStatus(c *gin.Context)
 }
\ No newline at end of file
@@ -1,8 +1,12 @@
 package QueryRequest
 
 import (
+	"errors"
 	"net/http"
+	"strconv"
 	controllerInterfaces "taskmanager/RequestControllers/QueryRequest/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/RequestControllers/httpSetup/middleware"
 	serviceInterfaces "taskmanager/Services/QueryServices/TaskQueryService/interfaces"
 
 	"github.com/gin-gonic/gin"
@@ -25,16 +29,35 @@ func NewQueryApiController(
 }
 
 func (c *queryApiController) RegisterRoutes(router *gin.RouterGroup) {
-	router.GET("/tasks/active", c.GetActiveTasks)
-	router.GET("/tasks/history", c.GetTaskStatusHistory)
+	tasksRead := middleware.RequireScopes(jwt.ScopeTasksRead)
+
+	router.GET("/tasks/active", tasksRead, c.GetActiveTasks)
+	router.GET("/tasks/history", tasksRead, c.GetTaskStatusHistory)
+	router.GET("/tasks/search", tasksRead, c.SearchTasks)
+	router.GET("/tasks/:id", tasksRead, c.GetTask)
+	router.GET("/reports/departments", tasksRead, c.GetDepartmentReport)
+	router.GET("/reports/positions", tasksRead, c.GetPositionReport)
+	router.GET("/reports/hires", tasksRead, c.GetHireReport)
+	router.GET("/analytics/status", tasksRead, c.GetStatusAnalytics)
 }
 
 // GetActiveTasks godoc
 // @Summary Get active tasks for a client
-// @Description Retrieves all active tasks for a specific client
+// @Description Retrieves all active tasks for a specific client with the latest status of each task
 // @Tags queries
 // @Produce json
 // @Security Bearer
+// @Param status query string false "Only return tasks currently in this status" Enums(PENDING, IN_PROGRESS, COMPLETED, CANCELLED)
+// @Param department query string false "Only return tasks in this department (case-insensitive)"
+// @Param position query string false "Only return tasks with this position (case-insensitive)"
+// @Param hired_from query string false "Earliest hire date, YYYY-MM-DD"
+// @Param hired_to query string false "Latest hire date, YYYY-MM-DD"
+// @Param min_salary query number false "Lowest salary"
+// @Param max_salary query number false "Highest salary"
+// @Param sort query string false "Sort field; tasks are ordered by ID when omitted" Enums(name, hire_date, salary, created_at)
+// @Param order query string false "Sort order" Enums(asc, desc)
+// @Param limit query int false "Page size (1-500, default 50)"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} interfaces.TasksResponseDTO
 // @Failure 400 {object} interfaces.TasksResponseDTO
 // @Failure 500 {object} interfaces.TasksResponseDTO
@@ -47,6 +70,20 @@ func (c *queryApiController) GetActiveTasks(ctx *gin.Context) {
 		ctx,
 		clientName.(string),
 		clientID.(string),
+		serviceInterfaces.TaskFilter{
+			Status:     ctx.Query("status"),
+			Department: ctx.Query("department"),
+			Position:   ctx.Query("position"),
+			HiredFrom:  ctx.Query("hired_from"),
+			HiredTo:    ctx.Query("hired_to"),
+			MinSalary:  ctx.Query("min_salary"),
+			MaxSalary:  ctx.Query("max_salary"),
+		},
+		serviceInterfaces.TaskSort{
+			Field: ctx.Query("sort"),
+			Order: ctx.Query("order"),
+		},
+		pageRequest(ctx),
 	)
 	if err != nil {
 		c.logger.WithError(err).Error("Failed to get active tasks")
@@ -58,15 +95,23 @@ func (c *queryApiController) GetActiveTasks(ctx *gin.Context) {
 		return
 	}
 
+	if !response.Success {
+		// Invalid parameters, including a cursor that was tampered with or has gone stale
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
 	ctx.JSON(http.StatusOK, response)
 }
 
 // GetTaskStatusHistory godoc
 // @Summary Get task status history
-// @Description Retrieves task status history for a client
+// @Description Retrieves task status history for a client, newest first
 // @Tags queries
 // @Produce json
 // @Security Bearer
+// @Param limit query int false "Page size (1-500, default 50)"
+// @Param cursor query string false "next_cursor of the previous page"
 // @Success 200 {object} interfaces.StatusHistoryResponseDTO
 // @Failure 400 {object} interfaces.StatusHistoryResponseDTO
 // @Failure 500 {object} interfaces.StatusHistoryResponseDTO
@@ -80,6 +125,7 @@ func (c *queryApiController) GetTaskStatusHistory(ctx *gin.Context) {
 		ctx,
 		clientName.(string),
 		clientID.(string),
+		pageRequest(ctx),
 	)
 	if err != nil {
 		c.logger.WithError(err).Error("Failed to get task status history")
@@ -91,5 +137,226 @@ func (c *queryApiController) GetTaskStatusHistory(ctx *gin.Context) {
 		return
 	}
 
+	if !response.Success {
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetTask godoc
+// @Summary Get a task
+// @Description Retrieves every field of a task owned by the client together with its status timeline, oldest first
+// @Tags queries
+// @Produce json
+// @Security Bearer
+// @Param id path int true "Task ID"
+// @Success 200 {object} interfaces.TaskResponseDTO
+// @Failure 400 {object} interfaces.TaskResponseDTO
+// @Failure 404 {object} interfaces.TaskResponseDTO "Task not found"
+// @Failure 500 {object} interfaces.TaskResponseDTO
+// @Router /api/queries/tasks/{id} [get]
+func (c *queryApiController) GetTask(ctx *gin.Context) {
+	clientName, _ := ctx.Get("client_name")
+	clientID, _ := ctx.Get("client_id")
+
+	id, err := strconv.Atoi(ctx.Param("id"))
+	if err != nil || id <= 0 {
+		ctx.JSON(http.StatusNotFound, serviceInterfaces.TaskResponseDTO{
+			Success: false,
+			Message: serviceInterfaces.ErrTaskNotFound.Error(),
+		})
+		return
+	}
+
+	response, err := c.queryService.GetTask(
+		ctx,
+		clientName.(string),
+		clientID.(string),
+		id,
+	)
+	if errors.Is(err, serviceInterfaces.ErrTaskNotFound) {
+		ctx.JSON(http.StatusNotFound, response)
+		return
+	}
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to get task")
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Failed to retrieve task",
+			"errors":  []string{err.Error()},
+		})
+		return
+	}
+
 	ctx.JSON(http.StatusOK, response)
 }
+
+// SearchTasks godoc
+// @Summary Search tasks
+// @Description Searches the name, email and address of the client's active tasks, most relevant first. A task matches when it contains every word of q or contains q itself.
+// @Tags queries
+// @Produce json
+// @Security Bearer
+// @Param q query string true "Search text (2-100 characters)"
+// @Param limit query int false "Page size (1-500, default 50)"
+// @Param cursor query string false "next_cursor of the previous page"
+// @Success 200 {object} interfaces.TaskSearchResponseDTO
+// @Failure 400 {object} interfaces.TaskSearchResponseDTO
+// @Failure 500 {object} interfaces.TaskSearchResponseDTO
+// @Router /api/queries/tasks/search [get]
+func (c *queryApiController) SearchTasks(ctx *gin.Context) {
+	clientName, _ := ctx.Get("client_name")
+	clientID, _ := ctx.Get("client_id")
+
+	response, err := c.queryService.SearchTasks(
+		ctx,
+		clientName.(string),
+		clientID.(string),
+		ctx.Query("q"),
+		pageRequest(ctx),
+	)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to search tasks")
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Failed to search tasks",
+			"errors":  []string{err.Error()},
+		})
+		return
+	}
+
+	if !response.Success {
+		ctx.JSON(http.StatusBadRequest, response)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetDepartmentReport godoc
+// @Summary Get the department report
+// @Description Retrieves the headcount, average, minimum, maximum and total salary and average age of the client's active tasks per department
+// @Tags queries
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} interfaces.GroupReportResponseDTO
+// @Failure 500 {object} interfaces.GroupReportResponseDTO
+// @Router /api/queries/reports/departments [get]
+func (c *queryApiController) GetDepartmentReport(ctx *gin.Context) {
+	c.groupReport(ctx, "department")
+}
+
+// GetPositionReport godoc
+// @Summary Get the position report
+// @Description Retrieves the headcount, average, minimum, maximum and total salary and average age of the client's active tasks per position
+// @Tags queries
+// @Produce json
+// @Security Bearer
+// @Success 200 {object} interfaces.GroupReportResponseDTO
+// @Failure 500 {object} interfaces.GroupReportResponseDTO
+// @Router /api/queries/reports/positions [get]
+func (c *queryApiController) GetPositionReport(ctx *gin.Context) {
+	c.groupReport(ctx, "position")
+}
+
+// groupReport answers a report of the client's active tasks grouped by groupBy
+func (c *queryApiController) groupReport(ctx *gin.Context, groupBy string) {
+	clientName, _ := ctx.Get("client_name")
+	clientID, _ := ctx.Get("client_id")
+
+	response, err := c.queryService.GetGroupReport(
+		ctx,
+		clientName.(string),
+		clientID.(string),
+		groupBy,
+	)
+	if err != nil {
+		c.logger.WithError(err).WithField("group_by", groupBy).Error("Failed to get report")
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Failed to retrieve report",
+			"errors":  []string{err.Error()},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetHireReport godoc
+// @Summary Get the hire report
+// @Description Counts the client's active tasks by hire month or year, oldest first
+// @Tags queries
+// @Produce json
+// @Security Bearer
+// @Param period query string false "Period hires are counted by (default month)" Enums(month, year)
+// @Success 200 {object} interfaces.HireReportResponseDTO
+// @Failure 400 {object} interfaces.HireReportResponseDTO
+// @Failure 500 {object} interfaces.HireReportResponseDTO
+// @Router /api/queries/reports/hires [get]
+func (c *queryApiController) GetHireReport(ctx *gin.Context) {
+	clientName, _ := ctx.Get("client_name")
+	clientID, _ := ctx.Get("client_id")
+
+	response, err := c.queryService.GetHireReport(
+		ctx,
+		clientName.(string),
+		clientID.(string),
+		ctx.Query("period"),
+	)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to get hire report")
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Failed to retrieve report",
+			"errors":  []string{err.Error()},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// GetStatusAnalytics godoc
+// @Summary Get status analytics
+// @Description Reports the average, median, 90th and 95th percentile time the client's tasks spent in each status before their next change, the tasks completed in each of the last weeks, and the active tasks stuck IN_PROGRESS beyond the configured threshold
+// @Tags queries
+// @Produce json
+// @Security Bearer
+// @Param weeks query int false "Weeks of completion throughput, ending with the current week (1-104, default 12)"
+// @Success 200 {object} interfaces.StatusAnalyticsResponseDTO
+// @Failure 400 {object} interfaces.StatusAnalyticsResponseDTO
+// @Failure 500 {object} interfaces.StatusAnalyticsResponseDTO
+// @Router /api/queries/analytics/status [get]
+func (c *queryApiController) GetStatusAnalytics(ctx *gin.Context) {
+	clientName, _ := ctx.Get("client_name")
+	clientID, _ := ctx.Get("client_id")
+
+	response, err := c.queryService.GetStatusAnalytics(
+		ctx,
+		clientName.(string),
+		clientID.(string),
+		ctx.Query("weeks"),
+	)
+	if err != nil {
+		c.logger.WithError(err).Error("Failed to get status analytics")
+		ctx.JSON(http.StatusInternalServerError, gin.H{
+			"success": false,
+			"message": "Failed to retrieve status analytics",
+			"errors":  []string{err.Error()},
+		})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, response)
+}
+
+// pageRequest reads the pagination parameters from the query string
+func pageRequest(ctx *gin.Context) serviceInterfaces.PageRequest {
+	return serviceInterfaces.PageRequest{
+		Limit:  ctx.Query("limit"),
+		Cursor: ctx.Query("cursor"),
+	}
+}
@@ -6,4 +6,10 @@ type QueryApiController interface {
     RegisterRoutes(router *gin.RouterGroup)
     GetActiveTasks(c *gin.Context)
     GetTaskStatusHistory(c *gin.Context)
+    GetTask(c *gin.Context)
+    SearchTasks(c *gin.Context)
+    GetDepartmentReport(c *gin.Context)
+    GetPositionReport(c *gin.Context)
+    GetHireReport(c *gin.Context)
+    GetStatusAnalytics(c *gin.Context)
 }
\ No newline at end of file
@@ -2,15 +2,18 @@ package config
 
 import (
 	"fmt"
+	"os"
 
+	"github.com/google/uuid"
 	"github.com/spf13/viper"
 )
 
 type Config struct {
-	Server   ServerConfig   `mapstructure:"server" validate:"required"`
-	Database DatabaseConfig `mapstructure:"database" validate:"required"`
-	Import   ImportConfig   `mapstructure:"import" validate:"required"`
-	JWT      JWTConfig      `mapstructure:"jwt"`
+	Server    ServerConfig    `mapstructure:"server" validate:"required"`
+	Database  DatabaseConfig  `mapstructure:"database" validate:"required"`
+	Import    ImportConfig    `mapstructure:"import" validate:"required"`
+	JWT       JWTConfig       `mapstructure:"jwt"`
+	Analytics AnalyticsConfig `mapstructure:"analytics"`
 }
 
 type ServerConfig struct {
@@ -26,13 +29,121 @@ type DatabaseConfig struct {
 	SSLMode  string `mapstructure:"sslmode" validate:"required,oneof=disable enable verify-full"`
 }
 
+// Authentication modes of the API
+const (
+	// AuthModeJWT requires a valid bearer token on every protected route
+	AuthModeJWT = "jwt"
+	// AuthModeDev skips token validation and treats every caller as DevClient. It is
+	// refused when GIN_MODE is release.
+	AuthModeDev = "dev"
+)
+
 type JWTConfig struct {
-	SecretKey   string `mapstructure:"secret_key" validate:"required"`
-	ExpiryHours int    `mapstructure:"expiry_hours" validate:"required,min=1"`
+	// Keys are the keys tokens are validated with. Keep a retired key, or only its public
+	// half, until the tokens it signed have expired.
+	Keys []SigningKeyConfig `mapstructure:"keys"`
+	// SigningKeyID is the kid of the key new tokens are signed with
+	SigningKeyID string `mapstructure:"signing_key_id"`
+	// AccessTokenMinutes is the lifetime of access tokens
+	AccessTokenMinutes int `mapstructure:"access_token_minutes" validate:"min=1"`
+	// RefreshTokenHours is the lifetime of refresh tokens
+	RefreshTokenHours int `mapstructure:"refresh_token_hours" validate:"min=1"`
+	// Mode is AuthModeJWT (default) or AuthModeDev
+	Mode      string          `mapstructure:"mode" validate:"oneof=jwt dev"`
+	DevClient DevClientConfig `mapstructure:"dev_client"`
+	// AdminKey authorises the client administration routes through the X-Admin-Key
+	// header; the routes are disabled when it is empty
+	AdminKey string `mapstructure:"admin_key"`
+}
+
+// SigningKeyConfig names a PEM key file. The file holds an RSA or EC P-256 private key, or
+// a public key for a key that no longer signs.
+type SigningKeyConfig struct {
+	KeyID string `mapstructure:"kid"`
+	File  string `mapstructure:"file"`
+}
+
+// DevClientConfig is the identity every request is given in AuthModeDev
+type DevClientConfig struct {
+	ClientName string `mapstructure:"client_name"`
+	ClientID   string `mapstructure:"client_id"`
+}
+
+// Validate checks the authentication settings for the gin mode the server runs in
+func (c *JWTConfig) Validate(ginMode string) error {
+	switch c.Mode {
+	case AuthModeJWT:
+		if err := c.validateKeys(); err != nil {
+			return err
+		}
+		if c.AccessTokenMinutes < 1 {
+			return fmt.Errorf("jwt.access_token_minutes must be at least 1")
+		}
+		if c.RefreshTokenHours < 1 {
+			return fmt.Errorf("jwt.refresh_token_hours must be at least 1")
+		}
+	case AuthModeDev:
+		if ginMode == "release" {
+			return fmt.Errorf("jwt.mode %q is not allowed when GIN_MODE is release", AuthModeDev)
+		}
+		if c.DevClient.ClientName == "" || c.DevClient.ClientID == "" {
+			return fmt.Errorf("jwt.dev_client.client_name and jwt.dev_client.client_id are required in %q mode", AuthModeDev)
+		}
+		if _, err := uuid.Parse(c.DevClient.ClientID); err != nil {
+			return fmt.Errorf("jwt.dev_client.client_id must be a valid UUID")
+		}
+	default:
+		return fmt.Errorf("jwt.mode must be %q or %q", AuthModeJWT, AuthModeDev)
+	}
+	return nil
+}
+
+// validateKeys checks that every key is named and the signing key is one of them
+func (c *JWTConfig) validateKeys() error {
+	if len(c.Keys) == 0 {
+		return fmt.Errorf("jwt.keys requires at least one key")
+	}
+
+	signing := false
+	for i, key := range c.Keys {
+		if key.KeyID == "" || key.File == "" {
+			return fmt.Errorf("jwt.keys[%d] requires kid and file", i)
+		}
+		signing = signing || key.KeyID == c.SigningKeyID
+	}
+	if !signing {
+		return fmt.Errorf("jwt.signing_key_id %q must be the kid of one of jwt.keys", c.SigningKeyID)
+	}
+	return nil
+}
+
+// AnalyticsConfig tunes the status analytics query
+type AnalyticsConfig struct {
+	// StuckAfterHours is how long a task may stay IN_PROGRESS before it is reported as stuck
+	StuckAfterHours int `mapstructure:"stuck_after_hours" validate:"min=1"`
 }
 
 type ImportConfig struct {
-	Directory string `mapstructure:"directory" validate:"required,dir"`
+	Directory      string                          `mapstructure:"directory" validate:"required,dir"`
+	Workers        int                             `mapstructure:"workers" validate:"min=1"`
+	QueueSize      int                             `mapstructure:"queue_size" validate:"min=1"`
+	BatchSize      int                             `mapstructure:"batch_size" validate:"min=1"`
+	MaxUploadMB    int                             `mapstructure:"max_upload_mb" validate:"min=1"`
+	DefaultProfile string                          `mapstructure:"default_profile"`
+	Profiles       map[string]MappingProfileConfig `mapstructure:"profiles"`
+}
+
+// MappingProfileConfig maps the headers of an upstream export to task fields.
+// Columns are keyed by field: name, email, age, address, phone_number,
+// department, position, salary and hire_date.
+type MappingProfileConfig struct {
+	Columns map[string]ColumnMappingConfig `mapstructure:"columns"`
+}
+
+type ColumnMappingConfig struct {
+	Aliases  []string `mapstructure:"aliases"`
+	Optional bool     `mapstructure:"optional"`
+	Default  string   `mapstructure:"default"`
 }
 
 func InitializeConfig() (*Config, error) {
@@ -41,6 +152,16 @@ func InitializeConfig() (*Config, error) {
 	viper.AddConfigPath(".")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("import.workers", 2)
+	viper.SetDefault("import.queue_size", 100)
+	viper.SetDefault("import.batch_size", 5000)
+	viper.SetDefault("import.max_upload_mb", 100)
+	viper.SetDefault("import.default_profile", "standard")
+	viper.SetDefault("analytics.stuck_after_hours", 72)
+	viper.SetDefault("jwt.mode", AuthModeJWT)
+	viper.SetDefault("jwt.access_token_minutes", 15)
+	viper.SetDefault("jwt.refresh_token_hours", 720)
+
 	if err := viper.ReadInConfig(); err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
@@ -50,6 +171,10 @@ func InitializeConfig() (*Config, error) {
 		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
 	}
 
+	if err := config.JWT.Validate(os.Getenv("GIN_MODE")); err != nil {
+		return nil, fmt.Errorf("invalid jwt config: %w", err)
+	}
+
 	return &config, nil
 }
 
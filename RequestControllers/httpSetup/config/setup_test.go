@@ -0,0 +1,96 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJWTConfigValidate(t *testing.T) {
+	devClient := DevClientConfig{
+		ClientName: "Client Three Inc",
+		ClientID:   "1a1b24b8-f439-4334-a91c-ba30a814614c",
+	}
+
+	keys := []SigningKeyConfig{
+		{KeyID: "2025-01", File: "keys/2025-01.pem"},
+		{KeyID: "2024-07", File: "keys/2024-07.pub.pem"},
+	}
+
+	tests := []struct {
+		name    string
+		config  JWTConfig
+		ginMode string
+		message string
+	}{
+		{
+			name:    "JWT mode in release",
+			config:  JWTConfig{Mode: AuthModeJWT, Keys: keys, SigningKeyID: "2025-01", AccessTokenMinutes: 15, RefreshTokenHours: 720},
+			ginMode: "release",
+		},
+		{
+			name:    "JWT mode without keys",
+			config:  JWTConfig{Mode: AuthModeJWT, SigningKeyID: "2025-01", AccessTokenMinutes: 15, RefreshTokenHours: 720},
+			message: "jwt.keys",
+		},
+		{
+			name: "JWT mode with a key without file",
+			config: JWTConfig{
+				Mode:               AuthModeJWT,
+				Keys:               []SigningKeyConfig{{KeyID: "2025-01"}},
+				SigningKeyID:       "2025-01",
+				AccessTokenMinutes: 15,
+				RefreshTokenHours:  720,
+			},
+			message: "jwt.keys[0]",
+		},
+		{
+			name:    "JWT mode signing with an unknown key",
+			config:  JWTConfig{Mode: AuthModeJWT, Keys: keys, SigningKeyID: "2023-01", AccessTokenMinutes: 15, RefreshTokenHours: 720},
+			message: "signing_key_id",
+		},
+		{
+			name:    "JWT mode without refresh token lifetime",
+			config:  JWTConfig{Mode: AuthModeJWT, Keys: keys, SigningKeyID: "2025-01", AccessTokenMinutes: 15},
+			message: "refresh_token_hours",
+		},
+		{
+			name:    "Dev mode in debug",
+			config:  JWTConfig{Mode: AuthModeDev, DevClient: devClient},
+			ginMode: "debug",
+		},
+		{
+			name:    "Dev mode refused in release",
+			config:  JWTConfig{Mode: AuthModeDev, DevClient: devClient},
+			ginMode: "release",
+			message: "not allowed",
+		},
+		{
+			name:    "Dev mode without identity",
+			config:  JWTConfig{Mode: AuthModeDev},
+			message: "dev_client",
+		},
+		{
+			name:    "Dev mode with invalid client ID",
+			config:  JWTConfig{Mode: AuthModeDev, DevClient: DevClientConfig{ClientName: "Client", ClientID: "client"}},
+			message: "valid UUID",
+		},
+		{
+			name:    "Unknown mode",
+			config:  JWTConfig{Mode: "none", Keys: keys, SigningKeyID: "2025-01", AccessTokenMinutes: 15, RefreshTokenHours: 720},
+			message: "jwt.mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate(tt.ginMode)
+
+			if tt.message == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.message)
+			}
+		})
+	}
+}
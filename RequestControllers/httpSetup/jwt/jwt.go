@@ -2,58 +2,121 @@ package jwt
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt"
-)
-
-const (
-	DevMode       = true
-	DevClientName = "Client Three Inc"
-	DevClientID   = "1a1b24b8-f439-4334-a91c-ba30a814614c"
+	"github.com/google/uuid"
 )
 
 type ClientClaims struct {
 	ClientName string `json:"client_name"`
 	ClientID   string `json:"client_id"`
+	// Scopes are the scopes granted to the client when the token was issued
+	Scopes []string `json:"scopes,omitempty"`
 	jwt.StandardClaims
 }
 
+// ValidateClient checks that the claims identify a client that tasks and imports can
+// belong to
+func (c ClientClaims) ValidateClient() error {
+	if strings.TrimSpace(c.ClientName) == "" {
+		return fmt.Errorf("client name cannot be empty")
+	}
+
+	if _, err := uuid.Parse(c.ClientID); err != nil {
+		return fmt.Errorf("invalid client ID format: must be a valid UUID")
+	}
+
+	return nil
+}
+
 type JWTManager struct {
-	secretKey   string
-	expiryHours int
+	keys *KeySet
+	// accessTTL is the lifetime of the access tokens the manager generates
+	accessTTL time.Duration
+	devClient *ClientClaims
 }
 
-func NewJWTManager(secretKey string, expiryHours int) *JWTManager {
+// NewJWTManager creates a manager that signs and validates access tokens living accessTTL
+// with keys. A non-nil devClient switches on development mode: tokens are not
+// validated and every request is made as devClient. keys may be nil in development mode,
+// in which case no tokens can be generated.
+func NewJWTManager(keys *KeySet, accessTTL time.Duration, devClient *ClientClaims) *JWTManager {
 	return &JWTManager{
-		secretKey:   secretKey,
-		expiryHours: expiryHours,
+		keys:      keys,
+		accessTTL: accessTTL,
+		devClient: devClient,
 	}
 }
 
-func (m *JWTManager) GenerateToken(clientName, clientID string) (string, error) {
+// AccessTTL returns the lifetime of generated access tokens
+func (m *JWTManager) AccessTTL() time.Duration {
+	return m.accessTTL
+}
+
+// DevClient returns the identity of every request in development mode, or nil when tokens
+// are validated
+func (m *JWTManager) DevClient() *ClientClaims {
+	return m.devClient
+}
+
+// JWKS returns the public keys tokens are validated with
+func (m *JWTManager) JWKS() JWKS {
+	if m.keys == nil {
+		return JWKS{Keys: []JWK{}}
+	}
+	return m.keys.JWKS()
+}
+
+// GenerateToken signs an access token granting scopes to a client with the signing key,
+// named in the kid header, and returns it with its claims. Each token gets a unique ID
+// (jti) so that it can be revoked.
+func (m *JWTManager) GenerateToken(clientName, clientID string, scopes []string) (string, *ClientClaims, error) {
+	if m.keys == nil {
+		return "", nil, fmt.Errorf("no signing key configured")
+	}
+
 	claims := &ClientClaims{
 		ClientName: clientName,
 		ClientID:   clientID,
+		Scopes:     scopes,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(time.Hour * time.Duration(m.expiryHours)).Unix(),
+			Id:        uuid.NewString(),
+			ExpiresAt: time.Now().Add(m.accessTTL).Unix(),
 			IssuedAt:  time.Now().Unix(),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(m.secretKey))
+	signing := m.keys.signing
+	token := jwt.NewWithClaims(signing.method, claims)
+	token.Header["kid"] = signing.ID
+	signed, err := token.SignedString(signing.private)
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, claims, nil
 }
 
+// ValidateToken checks a token against the key named in its kid header. The algorithm
+// must be the one of that key, which rules out HMAC and unsigned tokens.
 func (m *JWTManager) ValidateToken(tokenStr string) (*ClientClaims, error) {
 	token, err := jwt.ParseWithClaims(
 		tokenStr,
 		&ClientClaims{},
 		func(token *jwt.Token) (interface{}, error) {
-			if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			if m.keys == nil {
+				return nil, fmt.Errorf("no validation key configured")
+			}
+			kid, _ := token.Header["kid"].(string)
+			key, ok := m.keys.byID[kid]
+			if !ok {
+				return nil, fmt.Errorf("unknown key ID: %q", kid)
+			}
+			if token.Method.Alg() != key.Algorithm() {
 				return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 			}
-			return []byte(m.secretKey), nil
+			return key.public, nil
 		},
 	)
 
@@ -62,7 +125,7 @@ func (m *JWTManager) ValidateToken(tokenStr string) (*ClientClaims, error) {
 	}
 
 	claims, ok := token.Claims.(*ClientClaims)
-	if !ok {
+	if !ok || claims.ClientName == "" || claims.ClientID == "" || claims.Id == "" {
 		return nil, fmt.Errorf("invalid token claims")
 	}
 
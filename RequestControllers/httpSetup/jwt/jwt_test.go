@@ -0,0 +1,243 @@
+package jwt
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testClientName = "Client One Corp"
+	testClientID   = "9ebcc92c-e186-41b3-834b-f75ab3f110ae"
+)
+
+// fatalIf stops the test on a setup error
+func fatalIf(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+}
+
+// encodePEM encodes der as a PEM block of the given type
+func encodePEM(blockType string, der []byte) []byte {
+	return pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+}
+
+// ecKey generates a P-256 key and returns it with its SEC 1 PEM encoding
+func ecKey(t *testing.T) (*ecdsa.PrivateKey, []byte) {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	fatalIf(t, err)
+	der, err := x509.MarshalECPrivateKey(private)
+	fatalIf(t, err)
+	return private, encodePEM("EC PRIVATE KEY", der)
+}
+
+// publicPEM returns the PKIX PEM encoding of a public key
+func publicPEM(t *testing.T, public interface{}) []byte {
+	der, err := x509.MarshalPKIXPublicKey(public)
+	fatalIf(t, err)
+	return encodePEM("PUBLIC KEY", der)
+}
+
+func TestParseKey(t *testing.T) {
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	fatalIf(t, err)
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(rsaPrivate)
+	fatalIf(t, err)
+	weakRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	fatalIf(t, err)
+	ecPrivate, ecPEM := ecKey(t)
+	p384, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	fatalIf(t, err)
+	p384DER, err := x509.MarshalECPrivateKey(p384)
+	fatalIf(t, err)
+
+	tests := []struct {
+		name      string
+		data      []byte
+		algorithm string
+		canSign   bool
+		message   string
+	}{
+		{name: "RSA PKCS#1 private key", data: encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPrivate)), algorithm: "RS256", canSign: true},
+		{name: "RSA PKCS#8 private key", data: encodePEM("PRIVATE KEY", pkcs8), algorithm: "RS256", canSign: true},
+		{name: "RSA public key", data: publicPEM(t, &rsaPrivate.PublicKey), algorithm: "RS256"},
+		{name: "EC private key", data: ecPEM, algorithm: "ES256", canSign: true},
+		{name: "EC public key", data: publicPEM(t, &ecPrivate.PublicKey), algorithm: "ES256"},
+		{name: "Short RSA key", data: encodePEM("RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(weakRSA)), message: "at least 2048 bits"},
+		{name: "EC key on another curve", data: encodePEM("EC PRIVATE KEY", p384DER), message: "P-256"},
+		{name: "Certificate", data: encodePEM("CERTIFICATE", []byte("certificate")), message: "unsupported PEM type"},
+		{name: "Not PEM", data: []byte("secret"), message: "not PEM encoded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseKey("key", tt.data)
+
+			if tt.message != "" {
+				assert.ErrorContains(t, err, tt.message)
+				return
+			}
+			fatalIf(t, err)
+			assert.Equal(t, tt.algorithm, key.Algorithm())
+			assert.Equal(t, tt.canSign, key.CanSign())
+		})
+	}
+}
+
+func TestNewKeySet(t *testing.T) {
+	private, privatePEM := ecKey(t)
+	signing, err := ParseKey("2025-01", privatePEM)
+	fatalIf(t, err)
+	retired, err := ParseKey("2024-07", publicPEM(t, &private.PublicKey))
+	fatalIf(t, err)
+
+	_, err = NewKeySet("2025-01", signing, retired)
+	assert.NoError(t, err)
+	_, err = NewKeySet("2023-01", signing, retired)
+	assert.ErrorContains(t, err, "not in the key set")
+	_, err = NewKeySet("2024-07", signing, retired)
+	assert.ErrorContains(t, err, "no private key")
+	_, err = NewKeySet("2025-01", signing, signing)
+	assert.ErrorContains(t, err, "duplicate key ID")
+}
+
+func TestKeyRotation(t *testing.T) {
+	_, oldPEM := ecKey(t)
+	oldKey, err := ParseKey("2024-07", oldPEM)
+	fatalIf(t, err)
+	_, newPEM := ecKey(t)
+	newKey, err := ParseKey("2025-01", newPEM)
+	fatalIf(t, err)
+
+	before, err := NewKeySet("2024-07", oldKey)
+	fatalIf(t, err)
+	oldToken, _, err := NewJWTManager(before, time.Hour, nil).GenerateToken(testClientName, testClientID, nil)
+	fatalIf(t, err)
+
+	// Signing moves to the new key; tokens of the old key still validate
+	during, err := NewKeySet("2025-01", newKey, oldKey)
+	fatalIf(t, err)
+	manager := NewJWTManager(during, time.Hour, nil)
+	newToken, _, err := manager.GenerateToken(testClientName, testClientID, []string{ScopeTasksRead})
+	fatalIf(t, err)
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(newToken, &ClientClaims{})
+	fatalIf(t, err)
+	assert.Equal(t, "2025-01", parsed.Header["kid"])
+	assert.Equal(t, "ES256", parsed.Header["alg"])
+
+	_, err = manager.ValidateToken(oldToken)
+	assert.NoError(t, err)
+	claims, err := manager.ValidateToken(newToken)
+	assert.NoError(t, err)
+	assert.Equal(t, testClientID, claims.ClientID)
+	assert.Equal(t, []string{ScopeTasksRead}, claims.Scopes)
+
+	// Once the old key is retired its tokens are refused
+	after, err := NewKeySet("2025-01", newKey)
+	fatalIf(t, err)
+	_, err = NewJWTManager(after, time.Hour, nil).ValidateToken(oldToken)
+	assert.ErrorContains(t, err, "unknown key ID")
+}
+
+func TestValidateTokenRejectsOtherAlgorithms(t *testing.T) {
+	_, privatePEM := ecKey(t)
+	key, err := ParseKey("2025-01", privatePEM)
+	fatalIf(t, err)
+	keys, err := NewKeySet("2025-01", key)
+	fatalIf(t, err)
+	manager := NewJWTManager(keys, time.Hour, nil)
+
+	claims := &ClientClaims{
+		ClientName: testClientName,
+		ClientID:   testClientID,
+		StandardClaims: jwt.StandardClaims{
+			Id:        "0b7e6a7e-3f4b-4f3e-9a43-7c1e5d9b2c01",
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	}
+
+	// An HMAC token keyed with the public key must not pass as the key's own token
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	hmacToken.Header["kid"] = "2025-01"
+	signed, err := hmacToken.SignedString(publicPEM(t, key.public))
+	fatalIf(t, err)
+	_, err = manager.ValidateToken(signed)
+	assert.Error(t, err)
+
+	unsignedToken := jwt.NewWithClaims(jwt.SigningMethodNone, claims)
+	unsignedToken.Header["kid"] = "2025-01"
+	unsigned, err := unsignedToken.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	fatalIf(t, err)
+	_, err = manager.ValidateToken(unsigned)
+	assert.Error(t, err)
+}
+
+func TestJWKS(t *testing.T) {
+	rsaPrivate, err := rsa.GenerateKey(rand.Reader, 2048)
+	fatalIf(t, err)
+	rsaKey, err := ParseKey("rsa", publicPEM(t, &rsaPrivate.PublicKey))
+	fatalIf(t, err)
+	ecPrivate, ecPEM := ecKey(t)
+	ecKey, err := ParseKey("ec", ecPEM)
+	fatalIf(t, err)
+	keys, err := NewKeySet("ec", ecKey, rsaKey)
+	fatalIf(t, err)
+
+	jwks := NewJWTManager(keys, time.Hour, nil).JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("Expected 2 keys, got %d", len(jwks.Keys))
+	}
+
+	ec := jwks.Keys[0]
+	assert.Equal(t, JWK{KeyType: "EC", Use: "sig", Algorithm: "ES256", KeyID: "ec", Curve: "P-256", X: ec.X, Y: ec.Y}, ec)
+	x, err := base64.RawURLEncoding.DecodeString(ec.X)
+	fatalIf(t, err)
+	assert.Len(t, x, 32)
+	assert.Equal(t, 0, new(big.Int).SetBytes(x).Cmp(ecPrivate.X))
+
+	rsaJWK := jwks.Keys[1]
+	assert.Equal(t, "RSA", rsaJWK.KeyType)
+	assert.Equal(t, "RS256", rsaJWK.Algorithm)
+	assert.Equal(t, "AQAB", rsaJWK.E)
+	n, err := base64.RawURLEncoding.DecodeString(rsaJWK.N)
+	fatalIf(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(n).Cmp(rsaPrivate.N))
+
+	assert.Empty(t, NewJWTManager(nil, time.Hour, nil).JWKS().Keys)
+}
+
+func TestValidateClient(t *testing.T) {
+	tests := []struct {
+		name    string
+		claims  ClientClaims
+		wantErr string
+	}{
+		{name: "Named client with a UUID", claims: ClientClaims{ClientName: testClientName, ClientID: testClientID}},
+		{name: "Blank name", claims: ClientClaims{ClientName: "  ", ClientID: testClientID}, wantErr: "client name"},
+		{name: "ID is not a UUID", claims: ClientClaims{ClientName: testClientName, ClientID: "client-1"}, wantErr: "client ID"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.claims.ValidateClient()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/golang-jwt/jwt"
+)
+
+// minRSABits is the smallest RSA modulus accepted for signing keys
+const minRSABits = 2048
+
+// Key is a key tokens are validated with and, when its private half is known, signed with.
+// RSA keys sign with RS256 and P-256 keys with ES256.
+type Key struct {
+	// ID is the kid header of the tokens the key signs
+	ID      string
+	method  jwt.SigningMethod
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// LoadKey reads a PEM key file; see ParseKey
+func LoadKey(id string, path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key %q: %w", id, err)
+	}
+	return ParseKey(id, data)
+}
+
+// ParseKey reads a PEM encoded RSA or EC P-256 key. A private key (PKCS#1, SEC 1 or
+// PKCS#8) signs and validates; a public key (PKIX or PKCS#1) only validates, which is how a
+// key is kept while the tokens it signed expire.
+func ParseKey(id string, data []byte) (*Key, error) {
+	if id == "" {
+		return nil, fmt.Errorf("key ID is required")
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("key %q is not PEM encoded", id)
+	}
+
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		parsed, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PUBLIC KEY":
+		parsed, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("key %q has unsupported PEM type %q", id, block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse key %q: %w", id, err)
+	}
+
+	key := &Key{ID: id}
+	switch k := parsed.(type) {
+	case *rsa.PrivateKey:
+		key.private, key.public = k, &k.PublicKey
+	case *ecdsa.PrivateKey:
+		key.private, key.public = k, &k.PublicKey
+	case *rsa.PublicKey, *ecdsa.PublicKey:
+		key.public = k
+	default:
+		return nil, fmt.Errorf("key %q must be an RSA or EC key", id)
+	}
+
+	switch public := key.public.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSABits {
+			return nil, fmt.Errorf("RSA key %q must be at least %d bits", id, minRSABits)
+		}
+		key.method = jwt.SigningMethodRS256
+	case *ecdsa.PublicKey:
+		if public.Curve != elliptic.P256() {
+			return nil, fmt.Errorf("EC key %q must use the P-256 curve", id)
+		}
+		key.method = jwt.SigningMethodES256
+	}
+	return key, nil
+}
+
+// Algorithm returns the JWS algorithm of the key, RS256 or ES256
+func (k *Key) Algorithm() string {
+	return k.method.Alg()
+}
+
+// CanSign reports whether the private half of the key is known
+func (k *Key) CanSign() bool {
+	return k.private != nil
+}
+
+// KeySet holds the keys tokens are validated with and the one new tokens are signed with.
+// Rotating keys means adding the new key, signing with it, and removing the old key once the
+// tokens it signed have expired.
+type KeySet struct {
+	signing *Key
+	keys    []*Key
+	byID    map[string]*Key
+}
+
+// NewKeySet creates a key set signing with the key identified by signingKeyID
+func NewKeySet(signingKeyID string, keys ...*Key) (*KeySet, error) {
+	set := &KeySet{
+		keys: keys,
+		byID: make(map[string]*Key, len(keys)),
+	}
+	for _, key := range keys {
+		if _, ok := set.byID[key.ID]; ok {
+			return nil, fmt.Errorf("duplicate key ID %q", key.ID)
+		}
+		set.byID[key.ID] = key
+	}
+
+	set.signing = set.byID[signingKeyID]
+	if set.signing == nil {
+		return nil, fmt.Errorf("signing key %q is not in the key set", signingKeyID)
+	}
+	if !set.signing.CanSign() {
+		return nil, fmt.Errorf("signing key %q has no private key", signingKeyID)
+	}
+	return set, nil
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517)
+type JWK struct {
+	KeyType   string `json:"kty" example:"EC"`
+	Use       string `json:"use" example:"sig"`
+	Algorithm string `json:"alg" example:"ES256"`
+	KeyID     string `json:"kid" example:"2025-01"`
+	// RSA modulus and exponent
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// EC curve and point
+	Curve string `json:"crv,omitempty" example:"P-256"`
+	X     string `json:"x,omitempty"`
+	Y     string `json:"y,omitempty"`
+}
+
+// JWKS is a JSON Web Key Set
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns the public keys of the set, in configuration order
+func (s *KeySet) JWKS() JWKS {
+	jwks := JWKS{Keys: make([]JWK, 0, len(s.keys))}
+	for _, key := range s.keys {
+		jwk := JWK{
+			Use:       "sig",
+			Algorithm: key.Algorithm(),
+			KeyID:     key.ID,
+		}
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.KeyType = "RSA"
+			jwk.N = encodeInt(public.N.Bytes())
+			jwk.E = encodeInt(big.NewInt(int64(public.E)).Bytes())
+		case *ecdsa.PublicKey:
+			// Coordinates are fixed-length, left padded with zeros
+			size := (public.Curve.Params().BitSize + 7) / 8
+			jwk.KeyType = "EC"
+			jwk.Curve = public.Curve.Params().Name
+			jwk.X = encodeInt(public.X.FillBytes(make([]byte, size)))
+			jwk.Y = encodeInt(public.Y.FillBytes(make([]byte, size)))
+		}
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+	return jwks
+}
+
+// encodeInt encodes big-endian bytes as base64url without padding
+func encodeInt(b []byte) string {
+	return base64.RawURLEncoding.EncodeToString(b)
+}
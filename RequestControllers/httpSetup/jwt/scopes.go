@@ -0,0 +1,39 @@
+package jwt
+
+// Scopes grant access to groups of routes. A token carries the scopes of its client.
+const (
+	// ScopeTasksRead allows the task queries, reports and analytics
+	ScopeTasksRead = "tasks:read"
+	// ScopeTasksWrite allows creating, changing and deleting single tasks
+	ScopeTasksWrite = "tasks:write"
+	// ScopeImportRun allows running imports and following import jobs
+	ScopeImportRun = "import:run"
+	// ScopeAdmin allows managing clients
+	ScopeAdmin = "admin"
+)
+
+// AllScopes lists every scope, in the order they are documented
+var AllScopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeImportRun, ScopeAdmin}
+
+// DefaultScopes are granted to a client registered without scopes
+var DefaultScopes = []string{ScopeTasksRead, ScopeTasksWrite, ScopeImportRun}
+
+// IsScope reports whether scope is one of AllScopes
+func IsScope(scope string) bool {
+	for _, known := range AllScopes {
+		if scope == known {
+			return true
+		}
+	}
+	return false
+}
+
+// HasScope reports whether the claims grant scope
+func (c *ClientClaims) HasScope(scope string) bool {
+	for _, granted := range c.Scopes {
+		if granted == scope {
+			return true
+		}
+	}
+	return false
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"context"
 	"net/http"
 	"strings"
 	"taskmanager/RequestControllers/httpSetup/jwt"
@@ -8,12 +9,18 @@ import (
 	"github.com/gin-gonic/gin"
 )
 
-func JWTAuthMiddleware(jwtManager *jwt.JWTManager) gin.HandlerFunc {
+// RevocationList reports whether an access token has been revoked before its expiry
+type RevocationList interface {
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+}
+
+func JWTAuthMiddleware(jwtManager *jwt.JWTManager, revocations RevocationList) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// Development mode bypass
-		if jwt.DevMode {
-			c.Set("client_name", jwt.DevClientName)
-			c.Set("client_id", jwt.DevClientID)
+		if devClient := jwtManager.DevClient(); devClient != nil {
+			c.Set("client_name", devClient.ClientName)
+			c.Set("client_id", devClient.ClientID)
+			c.Set("scopes", devClient.Scopes)
 			c.Next()
 			return
 		}
@@ -49,8 +56,28 @@ func JWTAuthMiddleware(jwtManager *jwt.JWTManager) gin.HandlerFunc {
 			return
 		}
 
+		// Fail closed: a token that cannot be checked is not accepted
+		revoked, err := revocations.IsRevoked(c.Request.Context(), claims.Id)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{
+				"success": false,
+				"message": "Failed to validate token",
+			})
+			c.Abort()
+			return
+		}
+		if revoked {
+			c.JSON(http.StatusUnauthorized, gin.H{
+				"success": false,
+				"message": "Token has been revoked",
+			})
+			c.Abort()
+			return
+		}
+
 		c.Set("client_name", claims.ClientName)
 		c.Set("client_id", claims.ClientID)
+		c.Set("scopes", claims.Scopes)
 
 		c.Next()
 	}
@@ -0,0 +1,199 @@
+package middleware
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"taskmanager/RequestControllers/httpSetup/jwt"
+
+	"github.com/gin-gonic/gin"
+	jwtgo "github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testKeyID      = "2025-01"
+	testClientName = "Client One Corp"
+	testClientID   = "9ebcc92c-e186-41b3-834b-f75ab3f110ae"
+)
+
+// revocationList is a RevocationList holding a fixed set of token IDs
+type revocationList struct {
+	revoked map[string]bool
+	err     error
+}
+
+func (l revocationList) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return l.revoked[jti], l.err
+}
+
+// serve runs a request with the given Authorization header through the middleware and
+// returns the response and the client the handler saw
+func serve(t *testing.T, manager *jwt.JWTManager, revocations RevocationList, authorization string) (*httptest.ResponseRecorder, string) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+
+	var clientID string
+	router.GET("/protected", JWTAuthMiddleware(manager, revocations), func(c *gin.Context) {
+		clientID = c.GetString("client_id")
+		c.Status(http.StatusOK)
+	})
+
+	request := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	if authorization != "" {
+		request.Header.Set("Authorization", authorization)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder, clientID
+}
+
+// newKey generates a P-256 key
+func newKey(t *testing.T) *ecdsa.PrivateKey {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	return private
+}
+
+// newManager creates a JWT manager signing with private under testKeyID
+func newManager(t *testing.T, private *ecdsa.PrivateKey, devClient *jwt.ClientClaims) *jwt.JWTManager {
+	der, err := x509.MarshalECPrivateKey(private)
+	if err != nil {
+		t.Fatalf("Failed to encode key: %v", err)
+	}
+	key, err := jwt.ParseKey(testKeyID, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("Failed to parse key: %v", err)
+	}
+	keys, err := jwt.NewKeySet(testKeyID, key)
+	if err != nil {
+		t.Fatalf("Failed to create key set: %v", err)
+	}
+	return jwt.NewJWTManager(keys, time.Hour, devClient)
+}
+
+// signed signs claims with key using method, naming testKeyID in the kid header
+func signed(t *testing.T, method jwtgo.SigningMethod, key interface{}, claims jwtgo.Claims) string {
+	unsigned := jwtgo.NewWithClaims(method, claims)
+	unsigned.Header["kid"] = testKeyID
+	token, err := unsigned.SignedString(key)
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestJWTAuthMiddleware(t *testing.T) {
+	private := newKey(t)
+	manager := newManager(t, private, nil)
+	valid, _, err := manager.GenerateToken(testClientName, testClientID, []string{jwt.ScopeTasksRead})
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	revoked, _, err := manager.GenerateToken(testClientName, testClientID, []string{jwt.ScopeTasksRead})
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	revokedClaims, err := manager.ValidateToken(revoked)
+	if err != nil {
+		t.Fatalf("Failed to read token: %v", err)
+	}
+	revocations := revocationList{revoked: map[string]bool{revokedClaims.Id: true}}
+
+	expired := signed(t, jwtgo.SigningMethodES256, private, &jwt.ClientClaims{
+		ClientName: testClientName,
+		ClientID:   testClientID,
+		StandardClaims: jwtgo.StandardClaims{
+			ExpiresAt: time.Now().Add(-time.Minute).Unix(),
+			IssuedAt:  time.Now().Add(-time.Hour).Unix(),
+		},
+	})
+	wrongKey := signed(t, jwtgo.SigningMethodES256, newKey(t), &jwt.ClientClaims{
+		ClientName: testClientName,
+		ClientID:   testClientID,
+		StandardClaims: jwtgo.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	})
+	unsigned := signed(t, jwtgo.SigningMethodNone, jwtgo.UnsafeAllowNoneSignatureType, &jwt.ClientClaims{
+		ClientName: testClientName,
+		ClientID:   testClientID,
+	})
+	hmac := signed(t, jwtgo.SigningMethodHS256, []byte("shared-secret"), &jwt.ClientClaims{
+		ClientName: testClientName,
+		ClientID:   testClientID,
+		StandardClaims: jwtgo.StandardClaims{
+			ExpiresAt: time.Now().Add(time.Hour).Unix(),
+		},
+	})
+	noClient := signed(t, jwtgo.SigningMethodES256, private, &jwtgo.StandardClaims{
+		ExpiresAt: time.Now().Add(time.Hour).Unix(),
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		status        int
+	}{
+		{name: "Valid token", authorization: "Bearer " + valid, status: http.StatusOK},
+		{name: "Missing header", authorization: "", status: http.StatusUnauthorized},
+		{name: "Missing bearer scheme", authorization: valid, status: http.StatusUnauthorized},
+		{name: "Wrong scheme", authorization: "Basic " + valid, status: http.StatusUnauthorized},
+		{name: "Malformed token", authorization: "Bearer not.a.token", status: http.StatusUnauthorized},
+		{name: "Expired token", authorization: "Bearer " + expired, status: http.StatusUnauthorized},
+		{name: "Token signed with another key", authorization: "Bearer " + wrongKey, status: http.StatusUnauthorized},
+		{name: "HMAC token", authorization: "Bearer " + hmac, status: http.StatusUnauthorized},
+		{name: "Unsigned token", authorization: "Bearer " + unsigned, status: http.StatusUnauthorized},
+		{name: "Token without client", authorization: "Bearer " + noClient, status: http.StatusUnauthorized},
+		{name: "Revoked token", authorization: "Bearer " + revoked, status: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder, clientID := serve(t, manager, revocations, tt.authorization)
+
+			assert.Equal(t, tt.status, recorder.Code)
+			if tt.status == http.StatusOK {
+				assert.Equal(t, testClientID, clientID)
+			} else {
+				assert.Empty(t, clientID)
+			}
+		})
+	}
+}
+
+func TestJWTAuthMiddlewareDevMode(t *testing.T) {
+	manager := newManager(t, newKey(t), &jwt.ClientClaims{
+		ClientName: testClientName,
+		ClientID:   testClientID,
+	})
+
+	recorder, clientID := serve(t, manager, revocationList{}, "")
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, testClientID, clientID)
+}
+
+func TestJWTAuthMiddlewareRevocationCheckFails(t *testing.T) {
+	manager := newManager(t, newKey(t), nil)
+	token, _, err := manager.GenerateToken(testClientName, testClientID, []string{jwt.ScopeTasksRead})
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	recorder, clientID := serve(t, manager, revocationList{err: errors.New("connection refused")}, "Bearer "+token)
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Empty(t, clientID)
+}
@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequireScopes lets a request through only if its token grants every one of scopes. It
+// runs after JWTAuthMiddleware, which puts the granted scopes on the context.
+func RequireScopes(scopes ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		granted := c.GetStringSlice("scopes")
+
+		var missing []string
+		for _, scope := range scopes {
+			if !contains(granted, scope) {
+				missing = append(missing, scope)
+			}
+		}
+
+		if len(missing) > 0 {
+			c.JSON(http.StatusForbidden, gin.H{
+				"success": false,
+				"message": "Missing required scope: " + strings.Join(missing, ", "),
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// contains reports whether values holds value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,52 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"taskmanager/RequestControllers/httpSetup/jwt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequireScopes(t *testing.T) {
+	tests := []struct {
+		name     string
+		granted  []string
+		required []string
+		status   int
+	}{
+		{name: "Granted scope", granted: []string{jwt.ScopeTasksRead}, required: []string{jwt.ScopeTasksRead}, status: http.StatusOK},
+		{name: "All of several scopes", granted: jwt.AllScopes, required: []string{jwt.ScopeTasksWrite, jwt.ScopeImportRun}, status: http.StatusOK},
+		{name: "Missing scope", granted: []string{jwt.ScopeTasksRead}, required: []string{jwt.ScopeTasksWrite}, status: http.StatusForbidden},
+		{name: "One of several scopes missing", granted: []string{jwt.ScopeTasksWrite}, required: []string{jwt.ScopeTasksWrite, jwt.ScopeImportRun}, status: http.StatusForbidden},
+		{name: "Admin does not imply other scopes", granted: []string{jwt.ScopeAdmin}, required: []string{jwt.ScopeTasksRead}, status: http.StatusForbidden},
+		{name: "No scopes", required: []string{jwt.ScopeTasksRead}, status: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			router := gin.New()
+
+			reached := false
+			router.GET("/protected", func(c *gin.Context) {
+				if tt.granted != nil {
+					c.Set("scopes", tt.granted)
+				}
+				c.Next()
+			}, RequireScopes(tt.required...), func(c *gin.Context) {
+				reached = true
+				c.Status(http.StatusOK)
+			})
+
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/protected", nil))
+
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, tt.status == http.StatusOK, reached)
+		})
+	}
+}
@@ -27,6 +27,7 @@ type RouterConfig struct {
 	Logger            *logrus.Logger
 	AuthController    authInterfaces.AuthController
 	JWTManager        *jwt.JWTManager
+	Revocations       middleware.RevocationList
 }
 
 func InitializeGin(logger *logrus.Logger) {
@@ -59,17 +60,21 @@ func SetupRouter(config RouterConfig) *gin.Engine {
 	// API routes
 	api := router.Group("/api")
 
-	// Auth routes (no JWT required)
+	// Auth routes (no JWT required, except client administration)
 	auth := api.Group("/auth")
 	config.AuthController.RegisterRoutes(auth)
 
-	// Query routes (with JWT)
+	// Token verification keys for other services (no JWT required)
+	router.GET("/.well-known/jwks.json", config.AuthController.JWKS)
+
+	// Query routes (with JWT, scopes declared per route)
 	queries := api.Group("/queries")
-	queries.Use(middleware.JWTAuthMiddleware(config.JWTManager))
+	queries.Use(middleware.JWTAuthMiddleware(config.JWTManager, config.Revocations))
 	config.QueryController.RegisterRoutes(queries)
 
-	// Command routes
+	// Command routes (with JWT, scopes declared per route)
 	commands := api.Group("/commands")
+	commands.Use(middleware.JWTAuthMiddleware(config.JWTManager, config.Revocations))
 	config.CommandController.RegisterRoutes(commands)
 
 	// Swagger
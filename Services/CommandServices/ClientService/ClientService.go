@@ -0,0 +1,220 @@
+package ClientService
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	repoInterfaces "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ClientService/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// secretBytes is the amount of randomness in a generated client secret
+const secretBytes = 32
+
+type clientService struct {
+	repo   repoInterfaces.ClientRepository
+	logger *logrus.Logger
+	// hashCost is the bcrypt cost of new secret hashes
+	hashCost int
+	// unknownClientHash is compared against when the client does not exist, so that unknown
+	// and known clients take as long to reject
+	unknownClientHash []byte
+}
+
+// NewClientService creates a new instance of ClientService
+func NewClientService(repo repoInterfaces.ClientRepository, logger *logrus.Logger) interfaces.ClientService {
+	unknownClientHash, err := bcrypt.GenerateFromPassword([]byte("unknown client"), bcrypt.DefaultCost)
+	if err != nil {
+		// Only fails for costs outside the bcrypt range
+		panic(fmt.Sprintf("failed to hash placeholder secret: %v", err))
+	}
+
+	return &clientService{
+		repo:              repo,
+		logger:            logger,
+		hashCost:          bcrypt.DefaultCost,
+		unknownClientHash: unknownClientHash,
+	}
+}
+
+func (s *clientService) Authenticate(ctx context.Context, clientName string, clientID string, secret string) (*jwt.ClientClaims, error) {
+	client, err := s.repo.GetClient(ctx, clientID)
+	if errors.Is(err, repoInterfaces.ErrClientNotFound) {
+		_ = bcrypt.CompareHashAndPassword(s.unknownClientHash, []byte(secret))
+		s.logger.WithField("client_id", clientID).Warn("Token requested for an unknown client")
+		return nil, interfaces.ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := bcrypt.CompareHashAndPassword([]byte(client.SecretHash), []byte(secret)); err != nil {
+		s.logger.WithField("client_id", clientID).Warn("Token requested with a wrong secret")
+		return nil, interfaces.ErrInvalidCredentials
+	}
+	if !client.IsActive || client.ClientName != clientName {
+		s.logger.WithField("client_id", clientID).Warn("Token requested for a disabled client or with a wrong name")
+		return nil, interfaces.ErrInvalidCredentials
+	}
+
+	return &jwt.ClientClaims{
+		ClientName: client.ClientName,
+		ClientID:   client.ClientID,
+		Scopes:     client.Scopes,
+	}, nil
+}
+
+func (s *clientService) RegisterClient(ctx context.Context, request schemas.RegisterClientRequestDTO) (*schemas.ClientResponseDTO, error) {
+	client := &schemas.ClientModel{
+		ClientID:   strings.TrimSpace(request.ClientID),
+		ClientName: strings.TrimSpace(request.ClientName),
+	}
+	if client.ClientName == "" {
+		return nil, fmt.Errorf("%w: client name cannot be empty", interfaces.ErrInvalidClient)
+	}
+	if client.ClientID == "" {
+		client.ClientID = uuid.NewString()
+	} else if _, err := uuid.Parse(client.ClientID); err != nil {
+		return nil, fmt.Errorf("%w: client ID must be a valid UUID", interfaces.ErrInvalidClient)
+	}
+
+	client.Scopes = jwt.DefaultScopes
+	if len(request.Scopes) > 0 {
+		scopes, err := normalizeScopes(request.Scopes)
+		if err != nil {
+			return nil, err
+		}
+		client.Scopes = scopes
+	}
+
+	secret, hash, err := s.newSecret()
+	if err != nil {
+		return nil, err
+	}
+	client.SecretHash = hash
+
+	if err := s.repo.CreateClient(ctx, client); err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"client_name": client.ClientName,
+		"client_id":   client.ClientID,
+	}).Info("Client registered")
+	return &schemas.ClientResponseDTO{
+		Success:      true,
+		Message:      "Client registered successfully",
+		Client:       client.MapToDTO(),
+		ClientSecret: secret,
+	}, nil
+}
+
+func (s *clientService) RotateSecret(ctx context.Context, clientID string) (*schemas.ClientResponseDTO, error) {
+	secret, hash, err := s.newSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.repo.UpdateClientSecret(ctx, clientID, hash)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	s.logger.WithField("client_id", clientID).Info("Client secret rotated")
+	return &schemas.ClientResponseDTO{
+		Success:      true,
+		Message:      "Client secret rotated successfully",
+		Client:       client.MapToDTO(),
+		ClientSecret: secret,
+	}, nil
+}
+
+func (s *clientService) UpdateScopes(ctx context.Context, clientID string, scopes []string) (*schemas.ClientResponseDTO, error) {
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := s.repo.UpdateClientScopes(ctx, clientID, scopes)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"client_id": clientID,
+		"scopes":    scopes,
+	}).Info("Client scopes updated")
+	return &schemas.ClientResponseDTO{
+		Success: true,
+		Message: "Client scopes updated successfully",
+		Client:  client.MapToDTO(),
+	}, nil
+}
+
+func (s *clientService) DisableClient(ctx context.Context, clientID string) (*schemas.ClientResponseDTO, error) {
+	client, err := s.repo.DisableClient(ctx, clientID)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	s.logger.WithField("client_id", clientID).Info("Client disabled")
+	return &schemas.ClientResponseDTO{
+		Success: true,
+		Message: "Client disabled successfully",
+		Client:  client.MapToDTO(),
+	}, nil
+}
+
+// newSecret generates a random client secret and its bcrypt hash
+func (s *clientService) newSecret() (string, string, error) {
+	buf := make([]byte, secretBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", fmt.Errorf("failed to generate client secret: %w", err)
+	}
+	secret := base64.RawURLEncoding.EncodeToString(buf)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), s.hashCost)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to hash client secret: %w", err)
+	}
+	return secret, string(hash), nil
+}
+
+// normalizeScopes trims and deduplicates scopes, rejecting unknown ones
+func normalizeScopes(scopes []string) ([]string, error) {
+	normalized := make([]string, 0, len(scopes))
+	seen := make(map[string]bool, len(scopes))
+	for _, scope := range scopes {
+		scope = strings.TrimSpace(scope)
+		if !jwt.IsScope(scope) {
+			return nil, fmt.Errorf("%w: unknown scope %q", interfaces.ErrInvalidClient, scope)
+		}
+		if !seen[scope] {
+			seen[scope] = true
+			normalized = append(normalized, scope)
+		}
+	}
+	return normalized, nil
+}
+
+// translateRepoError maps repository sentinels to the service's own
+func translateRepoError(err error) error {
+	switch {
+	case errors.Is(err, repoInterfaces.ErrClientNotFound):
+		return interfaces.ErrClientNotFound
+	case errors.Is(err, repoInterfaces.ErrDuplicateClient):
+		return interfaces.ErrDuplicateClient
+	default:
+		return err
+	}
+}
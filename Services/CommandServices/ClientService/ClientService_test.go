@@ -0,0 +1,281 @@
+package ClientService
+
+import (
+	"context"
+	"testing"
+
+	repoInterfaces "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ClientService/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	testClientName = "Client One Corp"
+	testClientID   = "9ebcc92c-e186-41b3-834b-f75ab3f110ae"
+)
+
+// MockClientRepository is a mock implementation of ClientRepository
+type MockClientRepository struct {
+	mock.Mock
+}
+
+func (m *MockClientRepository) CreateClient(ctx context.Context, client *schemas.ClientModel) error {
+	return m.Called(ctx, client).Error(0)
+}
+
+func (m *MockClientRepository) GetClient(ctx context.Context, clientID string) (*schemas.ClientModel, error) {
+	args := m.Called(ctx, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ClientModel), args.Error(1)
+}
+
+func (m *MockClientRepository) UpdateClientSecret(ctx context.Context, clientID string, secretHash string) (*schemas.ClientModel, error) {
+	args := m.Called(ctx, clientID, secretHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ClientModel), args.Error(1)
+}
+
+func (m *MockClientRepository) UpdateClientScopes(ctx context.Context, clientID string, scopes []string) (*schemas.ClientModel, error) {
+	args := m.Called(ctx, clientID, scopes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ClientModel), args.Error(1)
+}
+
+func (m *MockClientRepository) DisableClient(ctx context.Context, clientID string) (*schemas.ClientModel, error) {
+	args := m.Called(ctx, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ClientModel), args.Error(1)
+}
+
+// newTestService creates a client service that hashes with the cheapest bcrypt cost
+func newTestService(repo *MockClientRepository) *clientService {
+	service := NewClientService(repo, logrus.New()).(*clientService)
+	service.hashCost = bcrypt.MinCost
+	return service
+}
+
+// hashed returns the bcrypt hash of secret
+func hashed(t *testing.T, secret string) string {
+	hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("Failed to hash secret: %v", err)
+	}
+	return string(hash)
+}
+
+func TestAuthenticate(t *testing.T) {
+	ctx := context.Background()
+	client := &schemas.ClientModel{
+		ClientID:   testClientID,
+		ClientName: testClientName,
+		SecretHash: hashed(t, "s3cret"),
+		IsActive:   true,
+		Scopes:     []string{jwt.ScopeTasksRead},
+	}
+	disabled := *client
+	disabled.IsActive = false
+
+	tests := []struct {
+		name       string
+		client     *schemas.ClientModel
+		clientName string
+		secret     string
+		valid      bool
+	}{
+		{name: "Matching credentials", client: client, clientName: testClientName, secret: "s3cret", valid: true},
+		{name: "Wrong secret", client: client, clientName: testClientName, secret: "guess"},
+		{name: "Wrong client name", client: client, clientName: "Client Two LLC", secret: "s3cret"},
+		{name: "Disabled client", client: &disabled, clientName: testClientName, secret: "s3cret"},
+		{name: "Unknown client", clientName: testClientName, secret: "s3cret"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockClientRepository)
+			service := newTestService(repo)
+			if tt.client != nil {
+				repo.On("GetClient", ctx, testClientID).Return(tt.client, nil).Once()
+			} else {
+				repo.On("GetClient", ctx, testClientID).Return(nil, repoInterfaces.ErrClientNotFound).Once()
+			}
+
+			claims, err := service.Authenticate(ctx, tt.clientName, testClientID, tt.secret)
+
+			if tt.valid {
+				assert.NoError(t, err)
+				assert.Equal(t, testClientName, claims.ClientName)
+				assert.Equal(t, testClientID, claims.ClientID)
+				assert.Equal(t, []string{jwt.ScopeTasksRead}, claims.Scopes)
+			} else {
+				assert.ErrorIs(t, err, interfaces.ErrInvalidCredentials)
+				assert.Nil(t, claims)
+			}
+		})
+	}
+}
+
+func TestRegisterClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Secret is returned once and only its hash is stored", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+
+		var stored *schemas.ClientModel
+		repo.On("CreateClient", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*schemas.ClientModel)
+		}).Return(nil).Once()
+
+		response, err := service.RegisterClient(ctx, schemas.RegisterClientRequestDTO{
+			ClientName: " " + testClientName + " ",
+			ClientID:   testClientID,
+		})
+		assert.NoError(t, err)
+		assert.True(t, response.Success)
+		assert.Equal(t, testClientName, response.Client.ClientName)
+		assert.Equal(t, testClientID, response.Client.ClientID)
+		assert.NotEmpty(t, response.ClientSecret)
+		assert.NotContains(t, stored.SecretHash, response.ClientSecret)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(stored.SecretHash), []byte(response.ClientSecret)))
+		assert.Equal(t, jwt.DefaultScopes, stored.Scopes)
+	})
+
+	t.Run("Requested scopes are granted", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+
+		var stored *schemas.ClientModel
+		repo.On("CreateClient", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*schemas.ClientModel)
+		}).Return(nil).Once()
+
+		response, err := service.RegisterClient(ctx, schemas.RegisterClientRequestDTO{
+			ClientName: testClientName,
+			Scopes:     []string{jwt.ScopeAdmin, " " + jwt.ScopeTasksRead, jwt.ScopeAdmin},
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, []string{jwt.ScopeAdmin, jwt.ScopeTasksRead}, stored.Scopes)
+		assert.Equal(t, stored.Scopes, response.Client.Scopes)
+	})
+
+	t.Run("Client ID is generated when absent", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+		repo.On("CreateClient", ctx, mock.Anything).Return(nil).Once()
+
+		response, err := service.RegisterClient(ctx, schemas.RegisterClientRequestDTO{ClientName: testClientName})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, response.Client.ClientID)
+	})
+
+	t.Run("Duplicate client is reported", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+		repo.On("CreateClient", ctx, mock.Anything).Return(repoInterfaces.ErrDuplicateClient).Once()
+
+		_, err := service.RegisterClient(ctx, schemas.RegisterClientRequestDTO{ClientName: testClientName})
+		assert.ErrorIs(t, err, interfaces.ErrDuplicateClient)
+	})
+
+	t.Run("Invalid client is rejected before storing", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+
+		_, err := service.RegisterClient(ctx, schemas.RegisterClientRequestDTO{ClientName: " "})
+		assert.ErrorIs(t, err, interfaces.ErrInvalidClient)
+		_, err = service.RegisterClient(ctx, schemas.RegisterClientRequestDTO{ClientName: testClientName, ClientID: "client"})
+		assert.ErrorIs(t, err, interfaces.ErrInvalidClient)
+		_, err = service.RegisterClient(ctx, schemas.RegisterClientRequestDTO{ClientName: testClientName, Scopes: []string{"tasks:delete"}})
+		assert.ErrorIs(t, err, interfaces.ErrInvalidClient)
+		repo.AssertNotCalled(t, "CreateClient", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRotateSecret(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("New secret replaces the stored hash", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+
+		var hash string
+		repo.On("UpdateClientSecret", ctx, testClientID, mock.Anything).Run(func(args mock.Arguments) {
+			hash = args.String(2)
+		}).Return(&schemas.ClientModel{ClientID: testClientID, ClientName: testClientName, IsActive: true}, nil).Once()
+
+		response, err := service.RotateSecret(ctx, testClientID)
+		assert.NoError(t, err)
+		assert.NotEmpty(t, response.ClientSecret)
+		assert.NoError(t, bcrypt.CompareHashAndPassword([]byte(hash), []byte(response.ClientSecret)))
+	})
+
+	t.Run("Unknown client is not found", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+		repo.On("UpdateClientSecret", ctx, testClientID, mock.Anything).Return(nil, repoInterfaces.ErrClientNotFound).Once()
+
+		_, err := service.RotateSecret(ctx, testClientID)
+		assert.ErrorIs(t, err, interfaces.ErrClientNotFound)
+	})
+}
+
+func TestUpdateScopes(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Scopes are replaced", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+		scopes := []string{jwt.ScopeTasksRead, jwt.ScopeImportRun}
+		repo.On("UpdateClientScopes", ctx, testClientID, scopes).
+			Return(&schemas.ClientModel{ClientID: testClientID, ClientName: testClientName, Scopes: scopes}, nil).Once()
+
+		response, err := service.UpdateScopes(ctx, testClientID, scopes)
+		assert.NoError(t, err)
+		assert.Equal(t, scopes, response.Client.Scopes)
+	})
+
+	t.Run("Unknown scope is rejected", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+
+		_, err := service.UpdateScopes(ctx, testClientID, []string{jwt.ScopeTasksRead, "everything"})
+		assert.ErrorIs(t, err, interfaces.ErrInvalidClient)
+		repo.AssertNotCalled(t, "UpdateClientScopes", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown client is not found", func(t *testing.T) {
+		repo := new(MockClientRepository)
+		service := newTestService(repo)
+		repo.On("UpdateClientScopes", ctx, testClientID, mock.Anything).Return(nil, repoInterfaces.ErrClientNotFound).Once()
+
+		_, err := service.UpdateScopes(ctx, testClientID, []string{jwt.ScopeAdmin})
+		assert.ErrorIs(t, err, interfaces.ErrClientNotFound)
+	})
+}
+
+func TestDisableClient(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockClientRepository)
+	service := newTestService(repo)
+	repo.On("DisableClient", ctx, testClientID).
+		Return(&schemas.ClientModel{ClientID: testClientID, ClientName: testClientName}, nil).Once()
+
+	response, err := service.DisableClient(ctx, testClientID)
+	assert.NoError(t, err)
+	assert.False(t, response.Client.IsActive)
+	assert.Empty(t, response.ClientSecret)
+}
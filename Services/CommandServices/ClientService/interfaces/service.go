@@ -0,0 +1,43 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+)
+
+var (
+	// ErrInvalidCredentials is returned when a client ID, name and secret do not match an
+	// active client
+	ErrInvalidCredentials = errors.New("invalid client credentials")
+	// ErrInvalidClient is returned when a registration has no name, a malformed ID or an
+	// unknown scope
+	ErrInvalidClient = errors.New("invalid client")
+	// ErrClientNotFound is returned when no client has the given ID
+	ErrClientNotFound = errors.New("client not found")
+	// ErrDuplicateClient is returned when a client with the same ID or name already exists
+	ErrDuplicateClient = errors.New("client with the same ID or name already exists")
+)
+
+// ClientService checks client credentials and manages the client registry
+type ClientService interface {
+	// Authenticate returns the claims of the active client matching the ID, name and
+	// secret, or ErrInvalidCredentials
+	Authenticate(ctx context.Context, clientName string, clientID string, secret string) (*jwt.ClientClaims, error)
+
+	// RegisterClient creates a client with a new secret, returned once in the response
+	RegisterClient(ctx context.Context, request schemas.RegisterClientRequestDTO) (*schemas.ClientResponseDTO, error)
+
+	// RotateSecret issues a new secret for a client and invalidates the old one; a disabled
+	// client stays disabled
+	RotateSecret(ctx context.Context, clientID string) (*schemas.ClientResponseDTO, error)
+
+	// UpdateScopes replaces the scopes of a client. Tokens already issued keep their scopes
+	// until they are refreshed.
+	UpdateScopes(ctx context.Context, clientID string, scopes []string) (*schemas.ClientResponseDTO, error)
+
+	// DisableClient stops a client from requesting tokens
+	DisableClient(ctx context.Context, clientID string) (*schemas.ClientResponseDTO, error)
+}
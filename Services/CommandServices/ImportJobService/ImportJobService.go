@@ -0,0 +1,372 @@
+package ImportJobService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	repoInterfaces "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportJobService/interfaces"
+	importInterfaces "taskmanager/Services/CommandServices/ImportTaskService/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	// progressFlushInterval limits how often progress is written to the job table
+	progressFlushInterval = time.Second
+	// statusUpdateTimeout bounds job bookkeeping writes that must outlive the job context
+	statusUpdateTimeout = 5 * time.Second
+	// maxReportedErrors caps how many import errors are kept in the job error message
+	maxReportedErrors = 10
+)
+
+type importJob struct {
+	id         string
+	claims     jwt.ClientClaims
+	source     string
+	uploadPath string
+	opts       schemas.ImportOptions
+	ctx        context.Context
+	cancel     context.CancelFunc
+}
+
+type importJobService struct {
+	importService importInterfaces.ImportService
+	repo          repoInterfaces.ImportJobRepository
+	logger        *logrus.Logger
+	workers       int
+
+	queue    chan *importJob
+	baseCtx  context.Context
+	stopAll  context.CancelFunc
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	jobs     map[string]*importJob
+	stopping bool
+}
+
+// NewImportJobService creates a new instance of ImportJobService
+func NewImportJobService(
+	importService importInterfaces.ImportService,
+	repo repoInterfaces.ImportJobRepository,
+	logger *logrus.Logger,
+	workers int,
+	queueSize int,
+) interfaces.ImportJobService {
+	baseCtx, stopAll := context.WithCancel(context.Background())
+	return &importJobService{
+		importService: importService,
+		repo:          repo,
+		logger:        logger,
+		workers:       workers,
+		queue:         make(chan *importJob, queueSize),
+		baseCtx:       baseCtx,
+		stopAll:       stopAll,
+		jobs:          make(map[string]*importJob),
+	}
+}
+
+func (s *importJobService) Start() {
+	failed, err := s.repo.FailInterruptedImportJobs(s.baseCtx)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to clean up interrupted import jobs")
+	} else if failed > 0 {
+		s.logger.WithField("job_count", failed).Warn("Marked interrupted import jobs as failed")
+	}
+
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.work()
+	}
+
+	s.logger.WithField("workers", s.workers).Info("Import job workers started")
+}
+
+func (s *importJobService) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.stopping = true
+	s.mu.Unlock()
+
+	s.stopAll()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		s.logger.Info("Import job workers stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("timed out waiting for import job workers: %w", ctx.Err())
+	}
+}
+
+func (s *importJobService) Submit(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	fileName string,
+	reader io.Reader,
+	opts schemas.ImportOptions,
+) (*schemas.ImportJobDTO, error) {
+	job := &importJob{
+		claims: claims,
+		source: "import directory",
+		opts:   opts,
+	}
+
+	if reader != nil {
+		uploadPath, err := spoolUpload(reader)
+		if err != nil {
+			return nil, err
+		}
+		job.source = fileName
+		job.uploadPath = uploadPath
+	}
+
+	model := &schemas.ImportJobModel{
+		ClientName: claims.ClientName,
+		ClientID:   claims.ClientID,
+		Source:     job.source,
+	}
+	if err := s.repo.CreateImportJob(ctx, model); err != nil {
+		job.removeUpload()
+		return nil, err
+	}
+
+	job.id = model.ID
+	job.ctx, job.cancel = context.WithCancel(s.baseCtx)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.stopping {
+		s.abandon(job, "Import job service is shutting down")
+		return nil, interfaces.ErrShuttingDown
+	}
+
+	select {
+	case s.queue <- job:
+		s.jobs[job.id] = job
+	default:
+		s.abandon(job, "Import job queue is full")
+		return nil, interfaces.ErrQueueFull
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"job_id":    job.id,
+		"client_id": claims.ClientID,
+		"source":    job.source,
+	}).Info("Import job queued")
+
+	return model.MapToDTO(), nil
+}
+
+func (s *importJobService) GetJob(ctx context.Context, claims jwt.ClientClaims, jobID string) (*schemas.ImportJobDTO, error) {
+	job, err := s.loadJob(ctx, claims, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return job.MapToDTO(), nil
+}
+
+func (s *importJobService) CancelJob(ctx context.Context, claims jwt.ClientClaims, jobID string) (*schemas.ImportJobDTO, error) {
+	job, err := s.loadJob(ctx, claims, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if job.IsFinished() {
+		return nil, interfaces.ErrJobFinished
+	}
+
+	s.mu.Lock()
+	if running, ok := s.jobs[jobID]; ok {
+		running.cancel()
+	}
+	s.mu.Unlock()
+
+	if job.Status == schemas.ImportJobQueued {
+		if _, err := s.repo.CancelQueuedImportJob(ctx, jobID, claims.ClientID); err != nil {
+			return nil, err
+		}
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"job_id":    jobID,
+		"client_id": claims.ClientID,
+	}).Info("Import job cancellation requested")
+
+	return s.GetJob(ctx, claims, jobID)
+}
+
+// loadJob fetches a job for the calling client, treating malformed IDs as unknown jobs
+func (s *importJobService) loadJob(ctx context.Context, claims jwt.ClientClaims, jobID string) (*schemas.ImportJobModel, error) {
+	if _, err := uuid.Parse(jobID); err != nil {
+		return nil, interfaces.ErrJobNotFound
+	}
+
+	job, err := s.repo.GetImportJob(ctx, jobID, claims.ClientID)
+	if errors.Is(err, repoInterfaces.ErrImportJobNotFound) {
+		return nil, interfaces.ErrJobNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func (s *importJobService) work() {
+	defer s.wg.Done()
+
+	for {
+		select {
+		case <-s.baseCtx.Done():
+			return
+		case job := <-s.queue:
+			s.process(job)
+		}
+	}
+}
+
+func (s *importJobService) process(job *importJob) {
+	defer func() {
+		job.cancel()
+		job.removeUpload()
+		s.mu.Lock()
+		delete(s.jobs, job.id)
+		s.mu.Unlock()
+	}()
+
+	logger := s.logger.WithField("job_id", job.id)
+
+	if job.ctx.Err() != nil {
+		logger.Info("Skipping import job cancelled before it started")
+		return
+	}
+
+	started, err := s.repo.StartImportJob(job.ctx, job.id)
+	if err != nil {
+		logger.WithError(err).Error("Failed to start import job")
+		return
+	}
+	if !started {
+		logger.Info("Import job is no longer queued")
+		return
+	}
+
+	logger.Info("Import job started")
+
+	var lastFlush time.Time
+	var latest schemas.ImportProgress
+	opts := job.opts
+	opts.OnProgress = func(progress schemas.ImportProgress) {
+		latest = progress
+		if time.Since(lastFlush) < progressFlushInterval {
+			return
+		}
+		lastFlush = time.Now()
+		s.saveProgress(job, progress)
+	}
+
+	response, importErr := s.runImport(job, opts)
+	s.saveProgress(job, latest)
+
+	status, message := s.outcome(job, response, importErr)
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusUpdateTimeout)
+	defer cancel()
+	if err := s.repo.FinishImportJob(ctx, job.id, status, message); err != nil {
+		logger.WithError(err).Error("Failed to record import job result")
+	}
+}
+
+func (s *importJobService) runImport(job *importJob, opts schemas.ImportOptions) (*schemas.ImportTaskResponseDTO, error) {
+	if job.uploadPath == "" {
+		return s.importService.Import(job.ctx, job.claims, opts)
+	}
+
+	file, err := os.Open(job.uploadPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	return s.importService.ImportFromReader(job.ctx, job.claims, job.source, file, opts)
+}
+
+// outcome decides the terminal status of a job from the result of its import
+func (s *importJobService) outcome(job *importJob, response *schemas.ImportTaskResponseDTO, err error) (string, string) {
+	switch {
+	case err == nil:
+		return schemas.ImportJobCompleted, ""
+	case s.baseCtx.Err() != nil:
+		return schemas.ImportJobFailed, "Interrupted by server shutdown"
+	case job.ctx.Err() != nil:
+		return schemas.ImportJobCancelled, "Cancelled by client"
+	case response != nil && len(response.Errors) > 0:
+		errs := response.Errors
+		if len(errs) > maxReportedErrors {
+			errs = append(errs[:maxReportedErrors:maxReportedErrors], fmt.Sprintf("and %d more errors", len(response.Errors)-maxReportedErrors))
+		}
+		return schemas.ImportJobFailed, strings.Join(errs, "; ")
+	default:
+		return schemas.ImportJobFailed, err.Error()
+	}
+}
+
+func (s *importJobService) saveProgress(job *importJob, progress schemas.ImportProgress) {
+	ctx, cancel := context.WithTimeout(context.Background(), statusUpdateTimeout)
+	defer cancel()
+
+	if err := s.repo.UpdateImportJobProgress(ctx, job.id, progress); err != nil {
+		s.logger.WithError(err).WithField("job_id", job.id).Warn("Failed to record import job progress")
+	}
+}
+
+// abandon marks a job that could not be queued as failed; callers must hold s.mu
+func (s *importJobService) abandon(job *importJob, reason string) {
+	job.cancel()
+	job.removeUpload()
+
+	ctx, cancel := context.WithTimeout(context.Background(), statusUpdateTimeout)
+	defer cancel()
+	if err := s.repo.FinishImportJob(ctx, job.id, schemas.ImportJobFailed, reason); err != nil {
+		s.logger.WithError(err).WithField("job_id", job.id).Error("Failed to record abandoned import job")
+	}
+}
+
+// spoolUpload copies an uploaded file to disk so the job can outlive the request
+func spoolUpload(reader io.Reader) (string, error) {
+	file, err := os.CreateTemp("", "import-job-*.upload")
+	if err != nil {
+		return "", fmt.Errorf("failed to create upload file: %w", err)
+	}
+	defer file.Close()
+
+	if _, err := io.Copy(file, reader); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to store uploaded file: %w", err)
+	}
+
+	return file.Name(), nil
+}
+
+func (j *importJob) removeUpload() {
+	if j.uploadPath != "" {
+		os.Remove(j.uploadPath)
+	}
+}
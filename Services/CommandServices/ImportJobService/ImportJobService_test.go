@@ -0,0 +1,249 @@
+package ImportJobService
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	repoInterfaces "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportJobService/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const jobUUID = "2f6b1f0c-8d1e-4f5a-9a53-7c1f4f0a9b21"
+
+var testClaims = jwt.ClientClaims{ClientName: "Client One Corp", ClientID: "9ebcc92c-e186-41b3-834b-f75ab3f110ae"}
+
+// MockImportService is a mock implementation of ImportService
+type MockImportService struct {
+	mock.Mock
+}
+
+func (m *MockImportService) Import(ctx context.Context, claims jwt.ClientClaims, opts schemas.ImportOptions) (*schemas.ImportTaskResponseDTO, error) {
+	args := m.Called(ctx, claims, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ImportTaskResponseDTO), args.Error(1)
+}
+
+func (m *MockImportService) ImportFromReader(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	fileName string,
+	reader io.Reader,
+	opts schemas.ImportOptions,
+) (*schemas.ImportTaskResponseDTO, error) {
+	args := m.Called(ctx, claims, fileName, reader, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ImportTaskResponseDTO), args.Error(1)
+}
+
+func (m *MockImportService) ValidateFromReader(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	fileName string,
+	reader io.Reader,
+	opts schemas.ImportOptions,
+) (*schemas.ImportValidationResponseDTO, error) {
+	args := m.Called(ctx, claims, fileName, reader, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ImportValidationResponseDTO), args.Error(1)
+}
+
+func (m *MockImportService) History(ctx context.Context, claims jwt.ClientClaims, limit int) (*schemas.ImportHistoryResponseDTO, error) {
+	args := m.Called(ctx, claims, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ImportHistoryResponseDTO), args.Error(1)
+}
+
+// MockImportJobRepository is a mock implementation of ImportJobRepository
+type MockImportJobRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportJobRepository) CreateImportJob(ctx context.Context, job *schemas.ImportJobModel) error {
+	args := m.Called(ctx, job)
+	job.ID = jobUUID
+	job.Status = schemas.ImportJobQueued
+	return args.Error(0)
+}
+
+func (m *MockImportJobRepository) StartImportJob(ctx context.Context, jobID string) (bool, error) {
+	args := m.Called(ctx, jobID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockImportJobRepository) UpdateImportJobProgress(ctx context.Context, jobID string, progress schemas.ImportProgress) error {
+	args := m.Called(ctx, jobID, progress)
+	return args.Error(0)
+}
+
+func (m *MockImportJobRepository) FinishImportJob(ctx context.Context, jobID string, status string, errorMessage string) error {
+	args := m.Called(ctx, jobID, status, errorMessage)
+	return args.Error(0)
+}
+
+func (m *MockImportJobRepository) CancelQueuedImportJob(ctx context.Context, jobID string, clientID string) (bool, error) {
+	args := m.Called(ctx, jobID, clientID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockImportJobRepository) GetImportJob(ctx context.Context, jobID string, clientID string) (*schemas.ImportJobModel, error) {
+	args := m.Called(ctx, jobID, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ImportJobModel), args.Error(1)
+}
+
+func (m *MockImportJobRepository) FailInterruptedImportJobs(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func TestProcessImportJob(t *testing.T) {
+	tests := []struct {
+		name         string
+		response     *schemas.ImportTaskResponseDTO
+		importErr    error
+		wantStatus   string
+		wantMessage  string
+		wantProgress schemas.ImportProgress
+	}{
+		{
+			name:         "Successful import completes the job",
+			response:     &schemas.ImportTaskResponseDTO{Success: true},
+			wantStatus:   schemas.ImportJobCompleted,
+			wantProgress: schemas.ImportProgress{RowsParsed: 2, RowsValidated: 2, RowsInserted: 2},
+		},
+		{
+			name: "Failed import records the import errors",
+			response: &schemas.ImportTaskResponseDTO{
+				Success: false,
+				Errors:  []string{"invalid age at row 2", "invalid salary at row 3"},
+			},
+			importErr:    errors.New("failed to read entries from CSV"),
+			wantStatus:   schemas.ImportJobFailed,
+			wantMessage:  "invalid age at row 2; invalid salary at row 3",
+			wantProgress: schemas.ImportProgress{RowsParsed: 2, RowsValidated: 2, RowsInserted: 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockImport := new(MockImportService)
+			mockRepo := new(MockImportJobRepository)
+			finished := make(chan struct{})
+
+			mockRepo.On("FailInterruptedImportJobs", mock.Anything).Return(int64(0), nil)
+			mockRepo.On("CreateImportJob", mock.Anything, mock.Anything).Return(nil).Once()
+			mockRepo.On("StartImportJob", mock.Anything, jobUUID).Return(true, nil).Once()
+			mockRepo.On("UpdateImportJobProgress", mock.Anything, jobUUID, mock.Anything).Return(nil)
+			mockRepo.On("FinishImportJob", mock.Anything, jobUUID, tt.wantStatus, tt.wantMessage).
+				Run(func(mock.Arguments) { close(finished) }).
+				Return(nil).Once()
+			mockImport.On("Import", mock.Anything, testClaims, mock.Anything).
+				Run(func(args mock.Arguments) {
+					args.Get(2).(schemas.ImportOptions).ReportProgress(tt.wantProgress)
+				}).
+				Return(tt.response, tt.importErr).Once()
+
+			service := NewImportJobService(mockImport, mockRepo, logrus.New(), 1, 1)
+			service.Start()
+			defer service.Stop(context.Background())
+
+			job, err := service.Submit(context.Background(), testClaims, "", nil, schemas.ImportOptions{})
+			assert.NoError(t, err)
+			assert.Equal(t, jobUUID, job.ID)
+			assert.Equal(t, schemas.ImportJobQueued, job.Status)
+
+			select {
+			case <-finished:
+			case <-time.After(time.Second):
+				t.Fatal("import job did not finish")
+			}
+
+			mockImport.AssertExpectations(t)
+			mockRepo.AssertExpectations(t)
+			mockRepo.AssertCalled(t, "UpdateImportJobProgress", mock.Anything, jobUUID, tt.wantProgress)
+		})
+	}
+}
+
+func TestCancelImportJob(t *testing.T) {
+	tests := []struct {
+		name      string
+		jobID     string
+		mockSetup func(*MockImportJobRepository)
+		wantErr   error
+	}{
+		{
+			name:  "Queued job is cancelled",
+			jobID: jobUUID,
+			mockSetup: func(m *MockImportJobRepository) {
+				m.On("GetImportJob", mock.Anything, jobUUID, testClaims.ClientID).
+					Return(&schemas.ImportJobModel{ID: jobUUID, Status: schemas.ImportJobQueued}, nil).Once()
+				m.On("CancelQueuedImportJob", mock.Anything, jobUUID, testClaims.ClientID).Return(true, nil).Once()
+				m.On("GetImportJob", mock.Anything, jobUUID, testClaims.ClientID).
+					Return(&schemas.ImportJobModel{ID: jobUUID, Status: schemas.ImportJobCancelled}, nil).Once()
+			},
+		},
+		{
+			name:  "Finished job cannot be cancelled",
+			jobID: jobUUID,
+			mockSetup: func(m *MockImportJobRepository) {
+				m.On("GetImportJob", mock.Anything, jobUUID, testClaims.ClientID).
+					Return(&schemas.ImportJobModel{ID: jobUUID, Status: schemas.ImportJobCompleted}, nil).Once()
+			},
+			wantErr: interfaces.ErrJobFinished,
+		},
+		{
+			name:  "Job owned by another client is not found",
+			jobID: jobUUID,
+			mockSetup: func(m *MockImportJobRepository) {
+				m.On("GetImportJob", mock.Anything, jobUUID, testClaims.ClientID).
+					Return(nil, repoInterfaces.ErrImportJobNotFound).Once()
+			},
+			wantErr: interfaces.ErrJobNotFound,
+		},
+		{
+			name:      "Malformed job ID is not found",
+			jobID:     "not-a-uuid",
+			mockSetup: func(m *MockImportJobRepository) {},
+			wantErr:   interfaces.ErrJobNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockImportJobRepository)
+			tt.mockSetup(mockRepo)
+
+			service := NewImportJobService(new(MockImportService), mockRepo, logrus.New(), 1, 1)
+			job, err := service.CancelJob(context.Background(), testClaims, tt.jobID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, job)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, schemas.ImportJobCancelled, job.Status)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
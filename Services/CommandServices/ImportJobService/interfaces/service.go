@@ -0,0 +1,45 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+)
+
+var (
+	// ErrJobNotFound is returned when a job does not exist for the calling client
+	ErrJobNotFound = errors.New("import job not found")
+	// ErrJobFinished is returned when cancelling a job that has already finished
+	ErrJobFinished = errors.New("import job has already finished")
+	// ErrQueueFull is returned when no more jobs can be accepted
+	ErrQueueFull = errors.New("import job queue is full")
+	// ErrShuttingDown is returned when jobs are submitted while the service is stopping
+	ErrShuttingDown = errors.New("import job service is shutting down")
+)
+
+// ImportJobService runs imports in the background and tracks their progress
+type ImportJobService interface {
+	// Start launches the worker pool
+	Start()
+
+	// Stop cancels running jobs and waits for the workers to exit
+	Stop(ctx context.Context) error
+
+	// Submit queues an import for the calling client; a nil reader imports the import directory
+	Submit(
+		ctx context.Context,
+		claims jwt.ClientClaims,
+		fileName string,
+		reader io.Reader,
+		opts schemas.ImportOptions,
+	) (*schemas.ImportJobDTO, error)
+
+	// GetJob retrieves a job owned by the calling client
+	GetJob(ctx context.Context, claims jwt.ClientClaims, jobID string) (*schemas.ImportJobDTO, error)
+
+	// CancelJob requests cancellation of a queued or running job owned by the calling client
+	CancelJob(ctx context.Context, claims jwt.ClientClaims, jobID string) (*schemas.ImportJobDTO, error)
+}
@@ -0,0 +1,87 @@
+package ImportTaskService
+
+import (
+	"fmt"
+	"strings"
+
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+)
+
+// columnLayout records where the columns of a mapping profile sit in one file
+type columnLayout struct {
+	columns   map[string]schemas.ColumnMapping
+	positions map[string]int
+	// width is the number of cells a row needs to hold every mapped column
+	width int
+}
+
+// newColumnLayout matches the header row of a file against a mapping profile
+func newColumnLayout(profile schemas.MappingProfile, headers []string) (*columnLayout, error) {
+	if len(headers) > 0 {
+		headers[0] = strings.TrimPrefix(headers[0], "\uFEFF")
+	}
+
+	layout := &columnLayout{
+		columns:   make(map[string]schemas.ColumnMapping, len(profile.Columns)),
+		positions: make(map[string]int, len(profile.Columns)),
+	}
+
+	var missing []string
+	for _, column := range profile.Columns {
+		layout.columns[column.Key] = column
+
+		position := -1
+		for i, header := range headers {
+			if !column.Matches(header) {
+				continue
+			}
+			if position >= 0 {
+				return nil, fmt.Errorf("column %s matches both %q (column %d) and %q (column %d)",
+					column.Header, headers[position], position+1, header, i+1)
+			}
+			position = i
+		}
+
+		if position < 0 {
+			if !column.Optional {
+				missing = append(missing, column.Header)
+			}
+			continue
+		}
+
+		layout.positions[column.Key] = position
+		if position+1 > layout.width {
+			layout.width = position + 1
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("CSV is missing required columns for profile %q: %s",
+			profile.Name, strings.Join(missing, ", "))
+	}
+
+	return layout, nil
+}
+
+// value returns the trimmed cell for a column, or its default when an optional column
+// is absent or empty
+func (l *columnLayout) value(row []string, key string) string {
+	column := l.columns[key]
+	if position, ok := l.positions[key]; ok {
+		if value := strings.TrimSpace(row[position]); value != "" || !column.Optional {
+			return value
+		}
+	}
+	return strings.TrimSpace(column.Default)
+}
+
+// position returns the index of a column in the file, if the file has it
+func (l *columnLayout) position(key string) (int, bool) {
+	position, ok := l.positions[key]
+	return position, ok
+}
+
+// header returns the name a column is reported under
+func (l *columnLayout) header(key string) string {
+	return l.columns[key].Header
+}
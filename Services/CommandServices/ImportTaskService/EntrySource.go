@@ -0,0 +1,144 @@
+package ImportTaskService
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/sirupsen/logrus"
+)
+
+// entrySource reads the rows of an import file one at a time, so files of any size are
+// imported with bounded memory
+type entrySource interface {
+	// Next returns the next row, or the reasons it could not be parsed. It returns
+	// io.EOF after the last row and an error when the file as a whole cannot be read.
+	Next(ctx context.Context) (parsedRow, []schemas.RowErrorDTO, error)
+
+	// Close releases anything held by the source
+	Close() error
+}
+
+// openEntrySource prepares a source for the format of an import file, reading its header
+// where the format has one. Errors concern the file as a whole.
+func (s *importService) openEntrySource(fileName string, source io.Reader, opts schemas.ImportOptions) (entrySource, error) {
+	format := opts.Format
+	if format == "" {
+		format = schemas.ImportFormatFromFileName(fileName)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"file":   fileName,
+		"format": format,
+	}).Info("Reading import file")
+
+	buffered := bufio.NewReader(source)
+	if bom, err := buffered.Peek(3); err == nil && bytes.Equal(bom, []byte{0xEF, 0xBB, 0xBF}) {
+		buffered.Discard(len(bom))
+	}
+
+	switch format {
+	case schemas.ImportFormatJSON:
+		return s.newJSONEntrySource(buffered)
+	case schemas.ImportFormatNDJSON:
+		return s.newNDJSONEntrySource(buffered), nil
+	case schemas.ImportFormatXLSX:
+		return s.newXLSXEntrySource(buffered, opts)
+	default:
+		return s.newCSVEntrySource(buffered, opts)
+	}
+}
+
+// csvEntrySource reads CSV records, matching the header row against a mapping profile
+type csvEntrySource struct {
+	service *importService
+	reader  *csv.Reader
+	layout  *columnLayout
+	rowNum  int
+}
+
+func (s *importService) newCSVEntrySource(source io.Reader, opts schemas.ImportOptions) (entrySource, error) {
+	reader := csv.NewReader(source)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	reader.LazyQuotes = true
+	reader.ReuseRecord = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("CSV file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV rows: %w", err)
+	}
+
+	profile, err := s.mappingProfile(opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	layout, err := newColumnLayout(profile, header)
+	if err != nil {
+		return nil, fmt.Errorf("invalid CSV format: %w", err)
+	}
+
+	return &csvEntrySource{
+		service: s,
+		reader:  reader,
+		layout:  layout,
+		rowNum:  1,
+	}, nil
+}
+
+func (c *csvEntrySource) Next(ctx context.Context) (parsedRow, []schemas.RowErrorDTO, error) {
+	if err := ctx.Err(); err != nil {
+		return parsedRow{}, nil, fmt.Errorf("import cancelled: %w", err)
+	}
+
+	row, err := c.reader.Read()
+	if errors.Is(err, io.EOF) {
+		return parsedRow{}, nil, io.EOF
+	}
+	if err != nil {
+		return parsedRow{}, nil, fmt.Errorf("failed to read CSV rows: %w", err)
+	}
+
+	c.rowNum++
+	entry, rowErrs := c.service.parseTableRow(c.layout, row, c.rowNum)
+	return entry, rowErrs, nil
+}
+
+func (c *csvEntrySource) Close() error {
+	return nil
+}
+
+// parseTableRow converts a CSV or worksheet row laid out by its header
+func (s *importService) parseTableRow(layout *columnLayout, row []string, rowNum int) (parsedRow, []schemas.RowErrorDTO) {
+	s.logger.WithFields(logrus.Fields{
+		"row_number": rowNum,
+		"row_data":   row,
+	}).Debug("Processing row")
+
+	if len(row) < layout.width {
+		rowErr := schemas.RowErrorDTO{
+			Row:    rowNum,
+			Reason: fmt.Sprintf("insufficient columns (expected %d, got %d)", layout.width, len(row)),
+		}
+		s.logger.WithError(rowErr).Error("Invalid row")
+		return parsedRow{}, []schemas.RowErrorDTO{rowErr}
+	}
+
+	entry, rowErrs := s.parseEntry(layout, row, rowNum)
+	if len(rowErrs) > 0 {
+		s.logger.WithField("row_number", rowNum).WithField("errors", rowErrs).Error("Failed to parse row")
+		return parsedRow{}, rowErrs
+	}
+
+	return parsedRow{number: rowNum, entry: entry}, nil
+}
@@ -0,0 +1,202 @@
+package ImportTaskService
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	importInterfaces "taskmanager/Services/CommandServices/ImportTaskService/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/sirupsen/logrus"
+)
+
+// ledgerTimeout bounds recording an import, which happens even if the import was cancelled
+const ledgerTimeout = 5 * time.Second
+
+// importTracked imports one file and records it in the import ledger. A file whose content
+// the client already imported successfully is refused unless the import is forced; the
+// check and the ledger entry of a successful import share the import's transaction.
+func (s *importService) importTracked(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	fileName string,
+	reader io.Reader,
+	opts schemas.ImportOptions,
+	progress *schemas.ImportProgress,
+) (*schemas.FileImportResultDTO, error) {
+	entry := &schemas.ImportLedgerModel{
+		ClientName: claims.ClientName,
+		ClientID:   claims.ClientID,
+		FileName:   filepath.Base(fileName),
+		Forced:     opts.Force,
+		StartedAt:  time.Now(),
+	}
+	result := &schemas.FileImportResultDTO{
+		FileName: entry.FileName,
+	}
+
+	hash, source, cleanup, err := hashFile(reader)
+	if err != nil {
+		result.ErrorCount = 1
+		result.Errors = []string{err.Error()}
+		return result, err
+	}
+	defer cleanup()
+	entry.FileHash = hash
+
+	// A cancelled import does not open a transaction
+	if err := ctx.Err(); err != nil {
+		err = fmt.Errorf("import cancelled: %w", err)
+		result.ErrorCount = 1
+		result.Errors = []string{err.Error()}
+		return result, err
+	}
+
+	batcher, err := beginTaskBatcher(ctx, s.repo, s.batchSize, opts.Upsert)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to begin import")
+		err = fmt.Errorf("failed to begin import: %w", err)
+		result.ErrorCount = 1
+		result.Errors = []string{err.Error()}
+		return result, err
+	}
+	defer batcher.Rollback()
+
+	// The lock is held until the import commits, so a concurrent import of the same
+	// content only gets past this check once this one is in the ledger or rolled back
+	previous, err := batcher.LockFile(ctx, claims.ClientID, hash)
+	switch {
+	case err == nil && !opts.Force:
+		batcher.Rollback()
+		err = fmt.Errorf("%w: same content as %s imported at %s, send force=true to import it again",
+			importInterfaces.ErrDuplicateFile, previous.FileName, previous.FinishedAt.Format(time.RFC3339))
+		s.logger.WithFields(logrus.Fields{
+			"file":      entry.FileName,
+			"file_hash": hash,
+			"ledger_id": previous.ID,
+		}).Warn("Refused duplicate import")
+
+		result.ErrorCount = 1
+		result.Errors = []string{err.Error()}
+		entry.RecordResult(result, err)
+		entry.Status = schemas.ImportLedgerDuplicate
+		s.recordImport(entry)
+		return result, err
+	case err != nil && !errors.Is(err, interfaces.ErrImportLedgerEntryNotFound):
+		result.ErrorCount = 1
+		result.Errors = []string{err.Error()}
+		return result, err
+	}
+
+	result, importErr := s.importEntries(ctx, claims, fileName, source, opts, progress, batcher)
+	entry.RecordResult(result, importErr)
+	if importErr != nil || !result.Success {
+		batcher.Rollback()
+		s.recordImport(entry)
+		return result, importErr
+	}
+
+	// A successful import is recorded inside its own transaction, so the ledger never misses
+	// a committed file
+	if err := batcher.Commit(ctx, entry); err != nil {
+		s.logger.WithError(err).Error("Failed to import entries")
+		err = fmt.Errorf("failed to import entries: %w", err)
+		result.Success = false
+		result.ErrorCount++
+		result.Errors = append(result.Errors, err.Error())
+		entry.RecordResult(result, err)
+		s.recordImport(entry)
+		return result, err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"file":            result.FileName,
+		"entry_count":     result.SuccessCount,
+		"inserted_count":  result.Inserted,
+		"updated_count":   result.Updated,
+		"unchanged_count": result.Unchanged,
+		"rejected_count":  result.ErrorCount,
+		"ledger_id":       entry.ID,
+	}).Info("File imported successfully")
+	return result, nil
+}
+
+// recordImport adds the entry of a refused or failed import to the ledger. Nothing was
+// committed by the import, so a failure is logged rather than returned.
+func (s *importService) recordImport(entry *schemas.ImportLedgerModel) {
+	ctx, cancel := context.WithTimeout(context.Background(), ledgerTimeout)
+	defer cancel()
+
+	if err := s.ledger.RecordImport(ctx, entry); err != nil {
+		s.logger.WithError(err).WithField("file", entry.FileName).Error("Failed to record import in ledger")
+	}
+}
+
+// History lists the files imported by the calling client, newest first
+func (s *importService) History(ctx context.Context, claims jwt.ClientClaims, limit int) (*schemas.ImportHistoryResponseDTO, error) {
+	if err := claims.ValidateClient(); err != nil {
+		return nil, fmt.Errorf("invalid client: %w", err)
+	}
+
+	entries, err := s.ledger.ListImports(ctx, claims.ClientID, limit)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to list import history")
+		return nil, err
+	}
+
+	imports := make([]schemas.ImportLedgerEntryDTO, len(entries))
+	for i := range entries {
+		imports[i] = entries[i].MapToDTO()
+	}
+
+	return &schemas.ImportHistoryResponseDTO{
+		Success: true,
+		Message: fmt.Sprintf("Found %d imports", len(imports)),
+		Imports: imports,
+	}, nil
+}
+
+// hashFile computes the hex SHA-256 of a file and returns a reader positioned at its start.
+// Seekable files are rewound; anything else is spooled to a temporary file first.
+func hashFile(reader io.Reader) (string, io.Reader, func(), error) {
+	hash := sha256.New()
+
+	if seeker, ok := reader.(io.ReadSeeker); ok {
+		if _, err := io.Copy(hash, seeker); err != nil {
+			return "", nil, nil, fmt.Errorf("failed to hash import file: %w", err)
+		}
+		if _, err := seeker.Seek(0, io.SeekStart); err != nil {
+			return "", nil, nil, fmt.Errorf("failed to rewind import file: %w", err)
+		}
+		return hex.EncodeToString(hash.Sum(nil)), seeker, func() {}, nil
+	}
+
+	spool, err := os.CreateTemp("", "import-*.upload")
+	if err != nil {
+		return "", nil, nil, fmt.Errorf("failed to create upload file: %w", err)
+	}
+	cleanup := func() {
+		spool.Close()
+		os.Remove(spool.Name())
+	}
+
+	if _, err := io.Copy(io.MultiWriter(spool, hash), reader); err != nil {
+		cleanup()
+		return "", nil, nil, fmt.Errorf("failed to store uploaded file: %w", err)
+	}
+	if _, err := spool.Seek(0, io.SeekStart); err != nil {
+		cleanup()
+		return "", nil, nil, fmt.Errorf("failed to rewind uploaded file: %w", err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), spool, cleanup, nil
+}
@@ -1,239 +1,526 @@
 package ImportTaskService
 
 import (
-	"bytes"
-	"encoding/csv"
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	importInterfaces "taskmanager/Services/CommandServices/ImportTaskService/interfaces"
 	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
 	validationInterfaces "taskmanager/Services/CommandServices/ImportTaskService/validation/interfaces"
 
 	"github.com/sirupsen/logrus"
 )
 
+const (
+	// progressInterval is the number of parsed rows between progress reports
+	progressInterval = 1000
+	// processedDirectory and failedDirectory receive files once they have been imported
+	processedDirectory = "processed"
+	failedDirectory    = "failed"
+	// maxRejectedRows caps the rejections reported in detail for one file
+	maxRejectedRows = 1000
+)
+
 type importService struct {
 	repo      interfaces.TaskCommandRepository
+	ledger    interfaces.ImportLedgerRepository
 	validator validationInterfaces.Validator
 	logger    *logrus.Logger
 	directory string
+	profiles  schemas.MappingProfiles
+	batchSize int
 }
 
 func NewImportService(
 	repo interfaces.TaskCommandRepository,
+	ledger interfaces.ImportLedgerRepository,
 	validator validationInterfaces.Validator,
 	logger *logrus.Logger,
 	directory string,
+	profiles schemas.MappingProfiles,
+	batchSize int,
 ) *importService {
 	return &importService{
 		repo:      repo,
+		ledger:    ledger,
 		validator: validator,
 		logger:    logger,
 		directory: directory,
+		profiles:  profiles,
+		batchSize: batchSize,
 	}
 }
 
-func (s *importService) Import() (*schemas.ImportTaskResponseDTO, error) {
+func (s *importService) Import(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	opts schemas.ImportOptions,
+) (*schemas.ImportTaskResponseDTO, error) {
 	stats := &schemas.ImportStatsDTO{
 		StartTime: time.Now(),
 	}
-	defer func() {
-		stats.EndTime = time.Now()
-		stats.DurationMS = stats.EndTime.Sub(stats.StartTime).Milliseconds()
-	}()
+	defer stats.Finish()
 
-	entries, errs := s.readEntriesFromCSV()
-	if len(errs) > 0 {
-		return s.createErrorResponse(errs, stats), fmt.Errorf("failed to read entries from CSV")
+	if err := claims.ValidateClient(); err != nil {
+		s.logger.WithError(err).Error("Rejected import for invalid client")
+		return s.createErrorResponse([]error{err}, stats), fmt.Errorf("invalid client: %w", err)
 	}
 
-	// Convert DTOs to models
-	var taskModels []schemas.TaskModel
-	for _, entry := range entries {
-		var model schemas.TaskModel
-		model.MapFromDTO(entry)
-		taskModels = append(taskModels, model)
+	if _, err := s.mappingProfile(opts.Profile); err != nil {
+		return s.createErrorResponse([]error{err}, stats), err
 	}
 
-	stats.TotalProcessed = len(taskModels)
-	s.logger.WithField("entry_count", len(taskModels)).Info("Entries read from CSV")
+	files, err := s.findImportFiles()
+	if err != nil {
+		return s.createErrorResponse([]error{err}, stats), err
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"client_name": claims.ClientName,
+		"client_id":   claims.ClientID,
+		"file_count":  len(files),
+	}).Info("Starting directory import for client")
+
+	progress := &schemas.ImportProgress{}
+	for _, filePath := range files {
+		result, err := s.importFile(ctx, claims, filePath, opts, progress)
+		if ctx.Err() != nil {
+			s.logger.WithError(err).WithField("file", filePath).Warn("Import cancelled, leaving file in place")
+			return s.createErrorResponse([]error{ctx.Err()}, stats), fmt.Errorf("import cancelled: %w", ctx.Err())
+		}
 
-	if err := s.validator.ValidateBatch(entries); err != nil {
-		s.logger.WithError(err).Error("Validation failed")
-		return s.createErrorResponse([]error{err}, stats), fmt.Errorf("validation failed: %w", err)
+		result.ArchivedTo, err = s.archiveFile(filePath, result.Success)
+		if err != nil {
+			s.logger.WithError(err).WithField("file", filePath).Error("Failed to archive imported file")
+			result.Errors = append(result.Errors, err.Error())
+		}
+
+		stats.AddFile(*result)
 	}
 
-	s.logger.Info("All entries passed validation")
+	return s.createResponse(stats)
+}
 
-	if err := s.repo.BulkCreateTasks(taskModels); err != nil {
-		s.logger.WithError(err).Error("Failed to import entries")
-		return s.createErrorResponse([]error{err}, stats), fmt.Errorf("failed to import entries: %w", err)
+func (s *importService) ImportFromReader(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	fileName string,
+	reader io.Reader,
+	opts schemas.ImportOptions,
+) (*schemas.ImportTaskResponseDTO, error) {
+	stats := &schemas.ImportStatsDTO{
+		StartTime: time.Now(),
+	}
+	defer stats.Finish()
+
+	if err := claims.ValidateClient(); err != nil {
+		s.logger.WithError(err).Error("Rejected import for invalid client")
+		return s.createErrorResponse([]error{err}, stats), fmt.Errorf("invalid client: %w", err)
+	}
+
+	if _, err := s.mappingProfile(opts.Profile); err != nil {
+		return s.createErrorResponse([]error{err}, stats), err
 	}
 
-	stats.SuccessCount = len(taskModels)
 	s.logger.WithFields(logrus.Fields{
-		"duration":    stats.DurationMS,
-		"entry_count": len(entries),
-	}).Info("Data import process completed successfully")
+		"client_name": claims.ClientName,
+		"client_id":   claims.ClientID,
+		"file":        fileName,
+	}).Info("Starting upload import for client")
+
+	result, err := s.importTracked(ctx, claims, fileName, reader, opts, &schemas.ImportProgress{})
+	if ctxErr := ctx.Err(); ctxErr != nil {
+		return s.createErrorResponse([]error{ctxErr}, stats), fmt.Errorf("import cancelled: %w", ctxErr)
+	}
+	if errors.Is(err, importInterfaces.ErrDuplicateFile) {
+		return s.createErrorResponse([]error{err}, stats), err
+	}
 
-	return &schemas.ImportTaskResponseDTO{
-		Success:      true,
-		Message:      "Import completed successfully",
-		ImportedAt:   time.Now(),
-		TotalEntries: len(entries),
-		Stats:        stats,
-	}, nil
+	stats.AddFile(*result)
+	return s.createResponse(stats)
 }
 
-func (s *importService) validateCSVHeaders(headers []string) error {
-	expectedHeaders := []string{
-		"Name",
-		"Email",
-		"Age",
-		"Address",
-		"Phone Number",
-		"Department",
-		"Position",
-		"Salary",
-		"Hire Date",
+func (s *importService) ValidateFromReader(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	fileName string,
+	reader io.Reader,
+	opts schemas.ImportOptions,
+) (*schemas.ImportValidationResponseDTO, error) {
+	response := &schemas.ImportValidationResponseDTO{
+		FileName: filepath.Base(fileName),
+	}
+
+	if err := claims.ValidateClient(); err != nil {
+		s.logger.WithError(err).Error("Rejected validation for invalid client")
+		return nil, fmt.Errorf("invalid client: %w", err)
 	}
 
-	if len(headers) < len(expectedHeaders) {
-		return fmt.Errorf("CSV is missing required columns. Expected: %v", expectedHeaders)
+	if _, err := s.mappingProfile(opts.Profile); err != nil {
+		return nil, err
 	}
 
-	headers[0] = strings.TrimPrefix(headers[0], "\uFEFF")
+	s.logger.WithFields(logrus.Fields{
+		"client_id": claims.ClientID,
+		"file":      fileName,
+	}).Info("Starting import validation")
+
+	cannotImport := func(err error) (*schemas.ImportValidationResponseDTO, error) {
+		response.Message = "File cannot be imported"
+		response.Errors = []string{err.Error()}
+		return response, nil
+	}
 
-	for i, expected := range expectedHeaders {
-		if !strings.EqualFold(headers[i], expected) {
-			return fmt.Errorf("invalid header at column %d. Expected: %s, Got: %s",
-				i+1, expected, headers[i])
+	source, err := s.openEntrySource(fileName, reader, opts)
+	if err != nil {
+		return cannotImport(err)
+	}
+	defer source.Close()
+
+	for {
+		row, problems, err := source.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("validation cancelled: %w", ctxErr)
+		}
+		if err != nil {
+			return cannotImport(err)
+		}
+
+		response.TotalRows++
+		if len(problems) == 0 {
+			problems = s.validateRow(row)
 		}
+		if len(problems) > 0 {
+			response.InvalidRows++
+			response.Problems = append(response.Problems, problems...)
+			continue
+		}
+		response.ValidRows++
+	}
+
+	response.Valid = len(response.Problems) == 0
+	if response.Valid {
+		response.Message = "File is valid"
+	} else {
+		response.Message = fmt.Sprintf("Found %d problems in %d of %d rows",
+			len(response.Problems), response.InvalidRows, response.TotalRows)
 	}
 
-	return nil
+	s.logger.WithFields(logrus.Fields{
+		"file":         response.FileName,
+		"valid_rows":   response.ValidRows,
+		"invalid_rows": response.InvalidRows,
+	}).Info("Import validation completed")
+	return response, nil
 }
 
-func (s *importService) readEntriesFromCSV() ([]schemas.TaskImportDTO, []error) {
-	var errors []error
-	files, err := filepath.Glob(filepath.Join(s.directory, "*.csv"))
+// importFile imports a single file from the import directory
+func (s *importService) importFile(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	filePath string,
+	opts schemas.ImportOptions,
+	progress *schemas.ImportProgress,
+) (*schemas.FileImportResultDTO, error) {
+	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, []error{fmt.Errorf("failed to find CSV files: %w", err)}
+		err = fmt.Errorf("failed to open import file: %w", err)
+		return &schemas.FileImportResultDTO{
+			FileName:   filepath.Base(filePath),
+			ErrorCount: 1,
+			Errors:     []string{err.Error()},
+		}, err
 	}
+	defer file.Close()
 
-	if len(files) == 0 {
-		return nil, []error{fmt.Errorf("no CSV files found in directory: %s", s.directory)}
+	return s.importTracked(ctx, claims, filePath, file, opts, progress)
+}
+
+// importEntries streams the entries of one file into the batcher under the calling client,
+// validating them as they are read. Each file is written in its own transaction, which
+// the caller commits or rolls back, so one bad file never affects another.
+func (s *importService) importEntries(
+	ctx context.Context,
+	claims jwt.ClientClaims,
+	fileName string,
+	reader io.Reader,
+	opts schemas.ImportOptions,
+	progress *schemas.ImportProgress,
+	batcher *taskBatcher,
+) (*schemas.FileImportResultDTO, error) {
+	result := &schemas.FileImportResultDTO{
+		FileName: filepath.Base(fileName),
 	}
+	fail := func(errs []error, err error) (*schemas.FileImportResultDTO, error) {
+		result.ErrorCount += len(errs)
+		for _, e := range errs {
+			result.Errors = append(result.Errors, e.Error())
+		}
+		return result, err
+	}
+
+	source, err := s.openEntrySource(fileName, reader, opts)
+	if err != nil {
+		return fail([]error{err}, fmt.Errorf("failed to read entries: %w", err))
+	}
+	defer source.Close()
+
+	for {
+		row, rowErrs, err := source.Next(ctx)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			s.logger.WithError(ctxErr).Warn("Import cancelled, discarding written entries")
+			return fail([]error{ctxErr}, fmt.Errorf("import cancelled: %w", ctxErr))
+		}
+		if err != nil {
+			return fail([]error{err}, fmt.Errorf("failed to read entries: %w", err))
+		}
+
+		result.TotalEntries++
+		if len(rowErrs) == 0 {
+			countParsed(opts, progress)
+			rowErrs = s.validateRow(row)
+		}
 
-	filePath := files[0]
-	s.logger.WithField("file", filePath).Info("Reading CSV file")
+		if len(rowErrs) > 0 {
+			if !opts.IsPartial() {
+				errs := make([]error, len(rowErrs))
+				for i, rowErr := range rowErrs {
+					errs[i] = rowErr
+				}
+				return fail(errs, fmt.Errorf("row %d rejected: %w", rowErrs[0].Row, rowErrs[0]))
+			}
+			result.AddRejectedRow(rowErrs, maxRejectedRows)
+			progress.RowsRejected++
+			continue
+		}
+		progress.RowsValidated++
 
-	content, err := os.ReadFile(filePath)
+		// Convert the entry to a model owned by the calling client
+		var model schemas.TaskModel
+		model.MapFromDTO(row.entry)
+		model.AssignClient(claims.ClientName, claims.ClientID)
+
+		written, err := batcher.Add(ctx, model)
+		if err != nil {
+			s.logger.WithError(err).Error("Failed to import entries")
+			return fail([]error{err}, fmt.Errorf("failed to import entries: %w", err))
+		}
+		if written > 0 {
+			progress.RowsInserted += written
+			opts.ReportProgress(*progress)
+		}
+	}
+
+	written, err := batcher.Flush(ctx)
 	if err != nil {
-		return nil, []error{fmt.Errorf("failed to read CSV file: %w", err)}
+		s.logger.WithError(err).Error("Failed to import entries")
+		return fail([]error{err}, fmt.Errorf("failed to import entries: %w", err))
 	}
+	progress.RowsInserted += written
+	opts.ReportProgress(*progress)
+
+	// A partial import only fails outright when it had nothing valid to keep
+	result.ImportWriteCounts = batcher.Counts()
+	result.SuccessCount = result.ImportWriteCounts.Total()
+	result.Success = result.SuccessCount > 0 || result.ErrorCount == 0
+	return result, nil
+}
 
-	content = bytes.TrimPrefix(content, []byte{0xEF, 0xBB, 0xBF})
+// validateRow runs every validation rule over a parsed row, returning a rejection for
+// each violation found
+func (s *importService) validateRow(row parsedRow) []schemas.RowErrorDTO {
+	var rejected []schemas.RowErrorDTO
+	for _, fieldErr := range s.validator.ValidateEntryFields(&row.entry) {
+		rejected = append(rejected, schemas.RowErrorDTO{
+			Row:    row.number,
+			Column: fieldErr.Field,
+			Reason: fieldErr.Message,
+		})
+	}
+	return rejected
+}
 
-	reader := csv.NewReader(bytes.NewReader(content))
-	reader.FieldsPerRecord = -1
-	reader.TrimLeadingSpace = true
-	reader.LazyQuotes = true
+// mappingProfile resolves the column mapping profile requested for an import
+func (s *importService) mappingProfile(name string) (schemas.MappingProfile, error) {
+	profile, ok := s.profiles.Lookup(name)
+	if !ok {
+		return schemas.MappingProfile{}, fmt.Errorf("%w %q: must be one of %s",
+			importInterfaces.ErrUnknownProfile, name, strings.Join(s.profiles.Names(), ", "))
+	}
+	return profile, nil
+}
 
-	rows, err := reader.ReadAll()
+// findImportFiles lists the CSV, JSON, NDJSON and XLSX files waiting in the import directory in a stable order
+func (s *importService) findImportFiles() ([]string, error) {
+	entries, err := os.ReadDir(s.directory)
 	if err != nil {
-		return nil, []error{fmt.Errorf("failed to read CSV rows: %w", err)}
+		return nil, fmt.Errorf("failed to find import files: %w", err)
 	}
 
-	if len(rows) == 0 {
-		return nil, []error{fmt.Errorf("CSV file is empty")}
+	var files []string
+	for _, entry := range entries {
+		if entry.Type().IsRegular() && schemas.IsImportFileName(entry.Name()) {
+			files = append(files, filepath.Join(s.directory, entry.Name()))
+		}
 	}
 
-	if err := s.validateCSVHeaders(rows[0]); err != nil {
-		return nil, []error{fmt.Errorf("invalid CSV format: %w", err)}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no import files found in directory: %s", s.directory)
 	}
 
-	s.logger.WithField("total_rows", len(rows)).Info("Total rows found in CSV")
+	sort.Strings(files)
+	return files, nil
+}
 
-	var entries []schemas.TaskImportDTO
-	for i, row := range rows[1:] {
-		s.logger.WithFields(logrus.Fields{
-			"row_number": i + 2,
-			"row_data":   row,
-		}).Debug("Processing row")
+// archiveFile moves an imported file into the processed or failed subdirectory so it is
+// never picked up again, returning its new location
+func (s *importService) archiveFile(filePath string, succeeded bool) (string, error) {
+	archiveDir := filepath.Join(s.directory, failedDirectory)
+	if succeeded {
+		archiveDir = filepath.Join(s.directory, processedDirectory)
+	}
 
-		if len(row) < 9 {
-			err := fmt.Errorf("row %d has insufficient columns (expected 9, got %d)", i+2, len(row))
-			s.logger.WithError(err).Error("Invalid row")
-			errors = append(errors, err)
-			continue
-		}
+	if err := os.MkdirAll(archiveDir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create archive directory: %w", err)
+	}
 
-		entry, err := s.parseEntry(row, i+2)
-		if err != nil {
-			s.logger.WithError(err).WithField("row_number", i+2).Error("Failed to parse row")
-			errors = append(errors, err)
-			continue
-		}
-		entries = append(entries, entry)
+	target := filepath.Join(archiveDir, filepath.Base(filePath))
+	if _, err := os.Stat(target); err == nil {
+		ext := filepath.Ext(target)
+		target = fmt.Sprintf("%s_%s%s", strings.TrimSuffix(target, ext), time.Now().Format("20060102T150405.000000000"), ext)
 	}
 
-	if len(errors) > 0 {
-		s.logger.WithField("error_count", len(errors)).Error("Encountered errors while reading CSV")
-		return entries, errors
+	if err := os.Rename(filePath, target); err != nil {
+		return "", fmt.Errorf("failed to archive %s: %w", filepath.Base(filePath), err)
 	}
 
-	s.logger.WithField("entry_count", len(entries)).Info("Finished reading entries from CSV")
-	return entries, nil
+	s.logger.WithFields(logrus.Fields{
+		"file":        filePath,
+		"archived_to": target,
+	}).Info("Archived imported file")
+	return target, nil
+}
+
+// parsedRow is an entry together with the row or record number it was read from
+type parsedRow struct {
+	number int
+	entry  schemas.TaskImportDTO
+}
+
+// countParsed records a parsed row, reporting progress every progressInterval rows
+func countParsed(opts schemas.ImportOptions, progress *schemas.ImportProgress) {
+	progress.RowsParsed++
+	if progress.RowsParsed%progressInterval == 0 {
+		opts.ReportProgress(*progress)
+	}
 }
 
-func (s *importService) parseEntry(row []string, rowNum int) (schemas.TaskImportDTO, error) {
-	age, err := strconv.Atoi(row[2])
+// parseEntry converts a CSV row into an entry, reporting every column that cannot be parsed
+func (s *importService) parseEntry(layout *columnLayout, row []string, rowNum int) (schemas.TaskImportDTO, []schemas.RowErrorDTO) {
+	var rowErrs []schemas.RowErrorDTO
+
+	age, err := strconv.Atoi(layout.value(row, schemas.ColumnAge))
 	if err != nil {
-		return schemas.TaskImportDTO{}, fmt.Errorf("invalid age at row %d: %w", rowNum, err)
+		rowErrs = append(rowErrs, schemas.RowErrorDTO{Row: rowNum, Column: layout.header(schemas.ColumnAge), Reason: "must be a whole number"})
 	}
 
-	salary, err := strconv.ParseFloat(row[7], 64)
+	salary, err := strconv.ParseFloat(layout.value(row, schemas.ColumnSalary), 64)
 	if err != nil {
-		return schemas.TaskImportDTO{}, fmt.Errorf("invalid salary at row %d: %w", rowNum, err)
+		rowErrs = append(rowErrs, schemas.RowErrorDTO{Row: rowNum, Column: layout.header(schemas.ColumnSalary), Reason: "must be a number"})
 	}
 
-	var hireDate time.Time
+	hireDate, err := parseHireDate(layout.value(row, schemas.ColumnHireDate))
+	if err != nil {
+		rowErrs = append(rowErrs, schemas.RowErrorDTO{Row: rowNum, Column: layout.header(schemas.ColumnHireDate), Reason: "date must be in DD/MM/YYYY format"})
+	}
+
+	if len(rowErrs) > 0 {
+		return schemas.TaskImportDTO{}, rowErrs
+	}
+
+	return schemas.TaskImportDTO{
+		Name:        layout.value(row, schemas.ColumnName),
+		Email:       layout.value(row, schemas.ColumnEmail),
+		Age:         age,
+		Address:     layout.value(row, schemas.ColumnAddress),
+		PhoneNumber: layout.value(row, schemas.ColumnPhoneNumber),
+		Department:  layout.value(row, schemas.ColumnDepartment),
+		Position:    layout.value(row, schemas.ColumnPosition),
+		Salary:      salary,
+		HireDate:    hireDate,
+	}, nil
+}
+
+// parseHireDate accepts the date formats found in upstream exports
+func parseHireDate(value string) (time.Time, error) {
 	dateFormats := []string{
 		"02/01/2006", // DD/MM/YYYY
 		"2006-01-02", // YYYY-MM-DD
 		"01/02/2006", // MM/DD/YYYY
 		"2006/01/02", // YYYY/MM/DD
+		time.RFC3339,
 	}
 
-	var parseErr error
+	var hireDate time.Time
+	var err error
 	for _, format := range dateFormats {
-		hireDate, parseErr = time.Parse(format, row[8])
-		if parseErr == nil {
-			break
+		hireDate, err = time.Parse(format, strings.TrimSpace(value))
+		if err == nil {
+			return hireDate, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// createResponse summarises the per-file results of an import
+func (s *importService) createResponse(stats *schemas.ImportStatsDTO) (*schemas.ImportTaskResponseDTO, error) {
+	var errorMessages []string
+	failedFiles := 0
+	for _, file := range stats.Files {
+		if !file.Success {
+			failedFiles++
+		}
+		for _, message := range file.Errors {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s: %s", file.FileName, message))
 		}
 	}
 
-	if parseErr != nil {
-		return schemas.TaskImportDTO{}, fmt.Errorf("invalid hire date at row %d: date must be in DD/MM/YYYY format", rowNum)
+	response := &schemas.ImportTaskResponseDTO{
+		Success:      failedFiles == 0,
+		Message:      "Import completed successfully",
+		ImportedAt:   time.Now(),
+		TotalEntries: stats.TotalProcessed,
+		Errors:       errorMessages,
+		Stats:        stats,
 	}
 
-	return schemas.TaskImportDTO{
-		Name:        strings.TrimSpace(row[0]),
-		Email:       strings.TrimSpace(row[1]),
-		Age:         age,
-		Address:     strings.TrimSpace(row[3]),
-		PhoneNumber: strings.TrimSpace(row[4]),
-		Department:  strings.TrimSpace(row[5]),
-		Position:    strings.TrimSpace(row[6]),
-		Salary:      salary,
-		HireDate:    hireDate,
-	}, nil
+	if failedFiles > 0 {
+		response.Message = fmt.Sprintf("Import failed for %d of %d files", failedFiles, len(stats.Files))
+		s.logger.WithField("failed_files", failedFiles).Error(response.Message)
+		return response, fmt.Errorf("import failed for %d of %d files", failedFiles, len(stats.Files))
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"file_count":  len(stats.Files),
+		"entry_count": stats.SuccessCount,
+	}).Info("Data import process completed successfully")
+	return response, nil
 }
 
 func (s *importService) createErrorResponse(errs []error, stats *schemas.ImportStatsDTO) *schemas.ImportTaskResponseDTO {
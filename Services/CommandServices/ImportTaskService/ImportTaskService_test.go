@@ -0,0 +1,1036 @@
+package ImportTaskService
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	repository "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportTaskService/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	"taskmanager/Services/CommandServices/ImportTaskService/validation"
+	taskSchemas "taskmanager/Services/CommandServices/TaskCommandService/schemas"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	clientOneUUID = "9ebcc92c-e186-41b3-834b-f75ab3f110ae"
+	clientTwoUUID = "34fb4178-bee7-4c5d-b13c-7a4ac405d56d"
+)
+
+// testBatchSize is large enough to write every fixture in one batch
+const testBatchSize = 100
+
+const validCSV = "\uFEFFName,Email,Age,Address,Phone Number,Department,Position,Salary,Hire Date\n" +
+	"John Doe,john@example.com,30,123 Elm St,555-1234,Sales,Manager,60000,15/01/2006\n" +
+	"Jane Dee,jane@example.com,20,Feria Street,777-6542,IT,Dev,40000,20/07/2007\n"
+
+// MockTaskCommandRepository is a mock implementation of TaskCommandRepository
+type MockTaskCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskCommandRepository) BeginTaskImport(ctx context.Context) (repository.TaskBatchWriter, error) {
+	args := m.Called(ctx)
+	if writer, ok := args.Get(0).(repository.TaskBatchWriter); ok {
+		return writer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) BeginTaskUpsert(ctx context.Context) (repository.TaskBatchWriter, error) {
+	args := m.Called(ctx)
+	if writer, ok := args.Get(0).(repository.TaskBatchWriter); ok {
+		return writer, args.Error(1)
+	}
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) CreateTask(ctx context.Context, task *schemas.TaskModel) error {
+	return m.Called(ctx, task).Error(0)
+}
+
+func (m *MockTaskCommandRepository) GetTask(ctx context.Context, clientID string, id int) (*schemas.TaskModel, error) {
+	args := m.Called(ctx, clientID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.TaskModel), args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) UpdateTask(ctx context.Context, task *schemas.TaskModel) error {
+	return m.Called(ctx, task).Error(0)
+}
+
+func (m *MockTaskCommandRepository) PatchTask(
+	ctx context.Context,
+	clientID string,
+	id int,
+	patch func(current schemas.TaskModel) (*schemas.TaskModel, error),
+) (*schemas.TaskModel, error) {
+	args := m.Called(ctx, clientID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.TaskModel), args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) DeactivateTask(ctx context.Context, clientID string, id int) error {
+	return m.Called(ctx, clientID, id).Error(0)
+}
+
+func (m *MockTaskCommandRepository) GetTaskStatus(ctx context.Context, clientID string, taskID int) (string, error) {
+	args := m.Called(ctx, clientID, taskID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) AppendTaskStatus(ctx context.Context, entry *taskSchemas.TaskStatusModel, expected string) error {
+	return m.Called(ctx, entry, expected).Error(0)
+}
+
+// MockTaskBatchWriter is a mock implementation of TaskBatchWriter
+type MockTaskBatchWriter struct {
+	mock.Mock
+}
+
+func (m *MockTaskBatchWriter) LockFile(ctx context.Context, clientID string, fileHash string) (*schemas.ImportLedgerModel, error) {
+	args := m.Called(ctx, clientID, fileHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ImportLedgerModel), args.Error(1)
+}
+
+// WriteBatch reports every task as inserted unless the expectation returns other counts
+func (m *MockTaskBatchWriter) WriteBatch(ctx context.Context, tasks []schemas.TaskModel) (schemas.ImportWriteCounts, error) {
+	args := m.Called(ctx, tasks)
+	if counts, ok := args.Get(0).(schemas.ImportWriteCounts); ok {
+		return counts, args.Error(1)
+	}
+	return schemas.ImportWriteCounts{Inserted: len(tasks)}, args.Error(1)
+}
+
+func (m *MockTaskBatchWriter) RecordImport(ctx context.Context, entry *schemas.ImportLedgerModel) error {
+	return m.Called(ctx, entry).Error(0)
+}
+
+func (m *MockTaskBatchWriter) Commit() error {
+	return m.Called().Error(0)
+}
+
+func (m *MockTaskBatchWriter) Rollback() error {
+	return m.Called().Error(0)
+}
+
+// MockImportLedgerRepository is a mock implementation of ImportLedgerRepository
+type MockImportLedgerRepository struct {
+	mock.Mock
+}
+
+func (m *MockImportLedgerRepository) RecordImport(ctx context.Context, entry *schemas.ImportLedgerModel) error {
+	args := m.Called(ctx, entry)
+	return args.Error(0)
+}
+
+func (m *MockImportLedgerRepository) ListImports(ctx context.Context, clientID string, limit int) ([]schemas.ImportLedgerModel, error) {
+	args := m.Called(ctx, clientID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]schemas.ImportLedgerModel), args.Error(1)
+}
+
+// newMockLedger returns a ledger that accepts every record
+func newMockLedger() *MockImportLedgerRepository {
+	ledger := new(MockImportLedgerRepository)
+	ledger.On("RecordImport", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return ledger
+}
+
+// newMockWriter returns a writer for a file the ledger has never seen
+func newMockWriter(t *testing.T) *MockTaskBatchWriter {
+	writer := new(MockTaskBatchWriter)
+	writer.On("LockFile", mock.Anything, mock.Anything, mock.Anything).
+		Return(nil, repository.ErrImportLedgerEntryNotFound).Once()
+	t.Cleanup(func() { writer.AssertExpectations(t) })
+	return writer
+}
+
+// expectImport makes the repository accept one file whose tasks arrive in a single batch
+// matching tasks
+func expectImport(t *testing.T, repo *MockTaskCommandRepository, tasks interface{}) *MockTaskBatchWriter {
+	writer := newMockWriter(t)
+	writer.On("WriteBatch", mock.Anything, tasks).Return(nil, nil).Once()
+	writer.On("RecordImport", mock.Anything, mock.Anything).Return(nil).Once()
+	writer.On("Commit").Return(nil).Once()
+
+	repo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+	return writer
+}
+
+// expectRollback makes the repository open the transaction of one file that writes
+// nothing and is rolled back
+func expectRollback(t *testing.T, repo *MockTaskCommandRepository) *MockTaskBatchWriter {
+	writer := newMockWriter(t)
+	writer.On("Rollback").Return(nil).Once()
+
+	repo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+	return writer
+}
+
+func setupImportDir(t *testing.T, content string) string {
+	return setupImportFiles(t, map[string]string{"tasks.csv": content})
+}
+
+func setupImportFiles(t *testing.T, files map[string]string) string {
+	dir := t.TempDir()
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatalf("Failed to write CSV fixture: %v", err)
+		}
+	}
+	return dir
+}
+
+func tasksOwnedBy(clientName, clientID string) interface{} {
+	return mock.MatchedBy(func(tasks []schemas.TaskModel) bool {
+		if len(tasks) == 0 {
+			return false
+		}
+		for _, task := range tasks {
+			if task.ClientName != clientName || task.ClientID != clientID {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+func TestImportAssignsCallingClient(t *testing.T) {
+	logger := logrus.New()
+
+	tests := []struct {
+		name   string
+		claims jwt.ClientClaims
+	}{
+		{
+			name:   "Client one owns its imported rows",
+			claims: jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID},
+		},
+		{
+			name:   "Client two owns its imported rows",
+			claims: jwt.ClientClaims{ClientName: "Client Two LLC", ClientID: clientTwoUUID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+			expectImport(t, mockRepo, tasksOwnedBy(tt.claims.ClientName, tt.claims.ClientID))
+
+			service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, setupImportDir(t, validCSV), schemas.MappingProfiles{}, testBatchSize)
+			response, err := service.Import(context.Background(), tt.claims, schemas.ImportOptions{})
+
+			assert.NoError(t, err)
+			assert.True(t, response.Success)
+			assert.Equal(t, 2, response.Stats.SuccessCount)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportRejectsInvalidClient(t *testing.T) {
+	logger := logrus.New()
+	dir := setupImportDir(t, validCSV)
+
+	tests := []struct {
+		name    string
+		claims  jwt.ClientClaims
+		message string
+	}{
+		{
+			name:    "Missing client",
+			claims:  jwt.ClientClaims{},
+			message: "client name cannot be empty",
+		},
+		{
+			name:    "Empty client ID",
+			claims:  jwt.ClientClaims{ClientName: "Client One Corp"},
+			message: "invalid client ID format",
+		},
+		{
+			name:    "Malformed client ID",
+			claims:  jwt.ClientClaims{ClientName: "Client One Corp", ClientID: "not-a-uuid"},
+			message: "invalid client ID format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+
+			service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, dir, schemas.MappingProfiles{}, testBatchSize)
+			response, err := service.Import(context.Background(), tt.claims, schemas.ImportOptions{})
+
+			assert.Error(t, err)
+			assert.False(t, response.Success)
+			assert.Contains(t, response.Errors[0], tt.message)
+			mockRepo.AssertNotCalled(t, "BeginTaskImport", mock.Anything)
+		})
+	}
+}
+
+func TestImportFromReader(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+
+	tests := []struct {
+		name      string
+		content   string
+		mockSetup func(*testing.T, *MockTaskCommandRepository)
+		verify    func(*testing.T, *schemas.ImportTaskResponseDTO, error)
+	}{
+		{
+			name:    "Valid upload",
+			content: validCSV,
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) {
+				expectImport(t, m, tasksOwnedBy(claims.ClientName, claims.ClientID))
+			},
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.NoError(t, err)
+				assert.True(t, response.Success)
+				assert.Equal(t, 2, response.TotalEntries)
+			},
+		},
+		{
+			name:      "Invalid header",
+			content:   "Name,Mail\nJohn Doe,john@example.com\n",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) { expectRollback(t, m) },
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.Error(t, err)
+				assert.False(t, response.Success)
+				assert.Contains(t, response.Errors[0], "invalid CSV format")
+			},
+		},
+		{
+			name: "Unparseable row",
+			content: "Name,Email,Age,Address,Phone Number,Department,Position,Salary,Hire Date\n" +
+				"John Doe,john@example.com,thirty,123 Elm St,555-1234,Sales,Manager,60000,15/01/2006\n",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) { expectRollback(t, m) },
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.Error(t, err)
+				assert.False(t, response.Success)
+				assert.Contains(t, response.Errors[0], "invalid age at row 2")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+			tt.mockSetup(t, mockRepo)
+
+			service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+			response, err := service.ImportFromReader(
+				context.Background(),
+				claims,
+				"upload.csv",
+				strings.NewReader(tt.content),
+				schemas.ImportOptions{},
+			)
+
+			tt.verify(t, response, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportReportsProgress(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+
+	mockRepo := new(MockTaskCommandRepository)
+	expectImport(t, mockRepo, mock.Anything)
+
+	var reports []schemas.ImportProgress
+	opts := schemas.ImportOptions{
+		OnProgress: func(progress schemas.ImportProgress) {
+			reports = append(reports, progress)
+		},
+	}
+
+	service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, setupImportDir(t, validCSV), schemas.MappingProfiles{}, testBatchSize)
+	_, err := service.Import(context.Background(), claims, opts)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, reports)
+	assert.Equal(t, schemas.ImportProgress{RowsParsed: 2, RowsValidated: 2, RowsInserted: 2}, reports[len(reports)-1])
+}
+
+func TestImportWritesInBatches(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+	oneTask := mock.MatchedBy(func(tasks []schemas.TaskModel) bool { return len(tasks) == 1 })
+
+	t.Run("Every batch is written in one transaction", func(t *testing.T) {
+		writer := newMockWriter(t)
+		writer.On("WriteBatch", mock.Anything, oneTask).Return(nil, nil).Twice()
+		writer.On("RecordImport", mock.Anything, mock.Anything).Return(nil).Once()
+		writer.On("Commit").Return(nil).Once()
+
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+
+		service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, 1)
+		response, err := service.ImportFromReader(context.Background(), claims, "upload.csv", strings.NewReader(validCSV), schemas.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, response.Stats.SuccessCount)
+		mockRepo.AssertExpectations(t)
+		writer.AssertExpectations(t)
+	})
+
+	t.Run("Strict failure rolls back batches already written", func(t *testing.T) {
+		badCSV := validCSV + "Bad Age,bad@example.com,thirty,1 Oak St,555-0000,Sales,Clerk,30000,01/02/2010\n"
+
+		writer := newMockWriter(t)
+		writer.On("WriteBatch", mock.Anything, oneTask).Return(nil, nil).Twice()
+		writer.On("Rollback").Return(nil).Once()
+
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+
+		service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, 1)
+		response, err := service.ImportFromReader(context.Background(), claims, "upload.csv", strings.NewReader(badCSV), schemas.ImportOptions{})
+
+		assert.Error(t, err)
+		assert.False(t, response.Success)
+		assert.Equal(t, 0, response.Stats.SuccessCount)
+		writer.AssertExpectations(t)
+		writer.AssertNotCalled(t, "RecordImport", mock.Anything, mock.Anything)
+		writer.AssertNotCalled(t, "Commit")
+	})
+}
+
+func TestImportUpsert(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+
+	writer := newMockWriter(t)
+	writer.On("WriteBatch", mock.Anything, tasksOwnedBy(claims.ClientName, claims.ClientID)).
+		Return(schemas.ImportWriteCounts{Updated: 1, Unchanged: 1}, nil).Once()
+	writer.On("RecordImport", mock.Anything, mock.Anything).Return(nil).Once()
+	writer.On("Commit").Return(nil).Once()
+
+	mockRepo := new(MockTaskCommandRepository)
+	mockRepo.On("BeginTaskUpsert", mock.Anything).Return(writer, nil).Once()
+
+	service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+	response, err := service.ImportFromReader(context.Background(), claims, "upload.csv", strings.NewReader(validCSV), schemas.ImportOptions{Upsert: true})
+
+	assert.NoError(t, err)
+	assert.True(t, response.Success)
+	assert.Equal(t, 2, response.Stats.SuccessCount)
+	assert.Equal(t, schemas.ImportWriteCounts{Updated: 1, Unchanged: 1}, response.Stats.ImportWriteCounts)
+	assert.Equal(t, schemas.ImportWriteCounts{Updated: 1, Unchanged: 1}, response.Stats.Files[0].ImportWriteCounts)
+	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "BeginTaskImport", mock.Anything)
+	writer.AssertExpectations(t)
+}
+
+func TestImportLedger(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+	sum := sha256.Sum256([]byte(validCSV))
+	validHash := hex.EncodeToString(sum[:])
+
+	previous := &schemas.ImportLedgerModel{
+		ID:         "5d0c3a52-1f0e-4a57-9a0e-3c3b8b1b6d11",
+		FileName:   "tasks.csv",
+		FileHash:   validHash,
+		Status:     schemas.ImportLedgerSucceeded,
+		FinishedAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC),
+	}
+	recorded := func(status string, forced bool) interface{} {
+		return mock.MatchedBy(func(entry *schemas.ImportLedgerModel) bool {
+			return entry.Status == status && entry.Forced == forced &&
+				entry.FileHash == validHash && entry.ClientID == claims.ClientID
+		})
+	}
+
+	// lockedWriter returns a writer whose file lock finds the given previous import
+	lockedWriter := func(t *testing.T, found *schemas.ImportLedgerModel) *MockTaskBatchWriter {
+		writer := new(MockTaskBatchWriter)
+		if found != nil {
+			writer.On("LockFile", mock.Anything, claims.ClientID, validHash).Return(found, nil).Once()
+		} else {
+			writer.On("LockFile", mock.Anything, claims.ClientID, validHash).
+				Return(nil, repository.ErrImportLedgerEntryNotFound).Once()
+		}
+		t.Cleanup(func() { writer.AssertExpectations(t) })
+		return writer
+	}
+
+	t.Run("Duplicate upload is refused", func(t *testing.T) {
+		writer := lockedWriter(t, previous)
+		writer.On("Rollback").Return(nil).Once()
+
+		ledger := new(MockImportLedgerRepository)
+		ledger.On("RecordImport", mock.Anything, recorded(schemas.ImportLedgerDuplicate, false)).Return(nil).Once()
+
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+
+		service := NewImportService(mockRepo, ledger, validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+		response, err := service.ImportFromReader(context.Background(), claims, "again.csv", strings.NewReader(validCSV), schemas.ImportOptions{})
+
+		assert.ErrorIs(t, err, interfaces.ErrDuplicateFile)
+		assert.False(t, response.Success)
+		assert.Contains(t, response.Errors[0], "force=true")
+		writer.AssertNotCalled(t, "WriteBatch", mock.Anything, mock.Anything)
+		writer.AssertNotCalled(t, "Commit")
+		ledger.AssertExpectations(t)
+	})
+
+	t.Run("Forced upload is imported again", func(t *testing.T) {
+		writer := lockedWriter(t, previous)
+		writer.On("WriteBatch", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		writer.On("RecordImport", mock.Anything, recorded(schemas.ImportLedgerSucceeded, true)).Return(nil).Once()
+		writer.On("Commit").Return(nil).Once()
+
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+
+		ledger := new(MockImportLedgerRepository)
+		service := NewImportService(mockRepo, ledger, validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+		response, err := service.ImportFromReader(context.Background(), claims, "again.csv", strings.NewReader(validCSV), schemas.ImportOptions{Force: true})
+
+		assert.NoError(t, err)
+		assert.True(t, response.Success)
+		ledger.AssertNotCalled(t, "RecordImport", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unseekable upload is hashed before import", func(t *testing.T) {
+		writer := lockedWriter(t, nil)
+		writer.On("WriteBatch", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		writer.On("RecordImport", mock.Anything, recorded(schemas.ImportLedgerSucceeded, false)).Return(nil).Once()
+		writer.On("Commit").Return(nil).Once()
+
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+
+		service := NewImportService(mockRepo, new(MockImportLedgerRepository), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+		response, err := service.ImportFromReader(context.Background(), claims, "body.csv", io.MultiReader(strings.NewReader(validCSV)), schemas.ImportOptions{})
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, response.Stats.SuccessCount)
+	})
+
+	t.Run("Failed commit is recorded as failed", func(t *testing.T) {
+		writer := lockedWriter(t, nil)
+		writer.On("WriteBatch", mock.Anything, mock.Anything).Return(nil, nil).Once()
+		writer.On("RecordImport", mock.Anything, recorded(schemas.ImportLedgerSucceeded, false)).Return(nil).Once()
+		writer.On("Commit").Return(assert.AnError).Once()
+		writer.On("Rollback").Return(nil).Once()
+
+		ledger := new(MockImportLedgerRepository)
+		ledger.On("RecordImport", mock.Anything, recorded(schemas.ImportLedgerFailed, false)).Return(nil).Once()
+
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+
+		service := NewImportService(mockRepo, ledger, validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+		response, err := service.ImportFromReader(context.Background(), claims, "upload.csv", strings.NewReader(validCSV), schemas.ImportOptions{})
+
+		assert.Error(t, err)
+		assert.False(t, response.Success)
+		assert.Contains(t, response.Stats.Files[0].Errors[0], "failed to import entries")
+		ledger.AssertExpectations(t)
+	})
+
+	t.Run("Duplicate file in the import directory is archived as failed", func(t *testing.T) {
+		writer := lockedWriter(t, previous)
+		writer.On("Rollback").Return(nil).Once()
+
+		ledger := new(MockImportLedgerRepository)
+		ledger.On("RecordImport", mock.Anything, recorded(schemas.ImportLedgerDuplicate, false)).Return(nil).Once()
+
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("BeginTaskImport", mock.Anything).Return(writer, nil).Once()
+
+		dir := setupImportDir(t, validCSV)
+		service := NewImportService(mockRepo, ledger, validation.NewDataValidator(logger), logger, dir, schemas.MappingProfiles{}, testBatchSize)
+		response, err := service.Import(context.Background(), claims, schemas.ImportOptions{})
+
+		assert.Error(t, err)
+		assert.False(t, response.Stats.Files[0].Success)
+		assert.FileExists(t, filepath.Join(dir, "failed", "tasks.csv"))
+		ledger.AssertExpectations(t)
+	})
+}
+
+func TestImportStopsWhenCancelled(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+
+	mockRepo := new(MockTaskCommandRepository)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, setupImportDir(t, validCSV), schemas.MappingProfiles{}, testBatchSize)
+	response, err := service.Import(ctx, claims, schemas.ImportOptions{})
+
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, response.Success)
+	mockRepo.AssertNotCalled(t, "BeginTaskImport", mock.Anything)
+}
+
+func TestImportProcessesEveryFile(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+	invalidCSV := "Name,Mail\nJohn Doe,john@example.com\n"
+
+	dir := setupImportFiles(t, map[string]string{
+		"c_tasks.csv": validCSV,
+		"a_tasks.csv": validCSV,
+		"b_tasks.csv": invalidCSV,
+		"notes.txt":   "not an import file",
+	})
+
+	// Files are imported in name order, so the invalid file opens the second transaction
+	mockRepo := new(MockTaskCommandRepository)
+	expectImport(t, mockRepo, tasksOwnedBy(claims.ClientName, claims.ClientID))
+	expectRollback(t, mockRepo)
+	expectImport(t, mockRepo, tasksOwnedBy(claims.ClientName, claims.ClientID))
+
+	service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, dir, schemas.MappingProfiles{}, testBatchSize)
+	response, err := service.Import(context.Background(), claims, schemas.ImportOptions{})
+
+	assert.Error(t, err)
+	assert.False(t, response.Success)
+	assert.Equal(t, 4, response.Stats.SuccessCount)
+	mockRepo.AssertExpectations(t)
+
+	files := response.Stats.Files
+	if assert.Len(t, files, 3) {
+		assert.Equal(t, []string{"a_tasks.csv", "b_tasks.csv", "c_tasks.csv"},
+			[]string{files[0].FileName, files[1].FileName, files[2].FileName})
+		assert.True(t, files[0].Success)
+		assert.False(t, files[1].Success)
+		assert.Contains(t, files[1].Errors[0], "invalid CSV format")
+		assert.True(t, files[2].Success)
+	}
+
+	assert.FileExists(t, filepath.Join(dir, "processed", "a_tasks.csv"))
+	assert.FileExists(t, filepath.Join(dir, "failed", "b_tasks.csv"))
+	assert.FileExists(t, filepath.Join(dir, "processed", "c_tasks.csv"))
+	assert.FileExists(t, filepath.Join(dir, "notes.txt"))
+
+	remaining, _ := filepath.Glob(filepath.Join(dir, "*.csv"))
+	assert.Empty(t, remaining)
+
+	// A second run finds nothing left to import
+	_, err = service.Import(context.Background(), claims, schemas.ImportOptions{})
+	assert.ErrorContains(t, err, "no import files found")
+	mockRepo.AssertNumberOfCalls(t, "BeginTaskImport", 3)
+}
+
+func TestImportPartialMode(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+	mixedCSV := validCSV +
+		"Bad Age,bad@example.com,thirty,1 Oak St,555-0000,Sales,Clerk,30000,01/02/2010\n" +
+		",nameless@example.com,40,2 Oak St,555-0001,Sales,Clerk,30000,01/02/2010\n" +
+		"Short Row,short@example.com\n"
+
+	tests := []struct {
+		name      string
+		mode      string
+		mockSetup func(*testing.T, *MockTaskCommandRepository)
+		verify    func(*testing.T, *schemas.ImportTaskResponseDTO, error)
+	}{
+		{
+			name: "Partial mode inserts the valid rows and reports the rest",
+			mode: schemas.ImportModePartial,
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) {
+				expectImport(t, m, mock.MatchedBy(func(tasks []schemas.TaskModel) bool {
+					return len(tasks) == 2
+				}))
+			},
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.NoError(t, err)
+				assert.True(t, response.Success)
+				assert.Equal(t, 5, response.TotalEntries)
+				assert.Equal(t, 2, response.Stats.SuccessCount)
+				assert.Equal(t, 3, response.Stats.ErrorCount)
+
+				rejected := response.Stats.Files[0].RejectedRows
+				if assert.Len(t, rejected, 3) {
+					assert.Equal(t, schemas.RowErrorDTO{Row: 4, Column: "Age", Reason: "must be a whole number"}, rejected[0])
+					assert.Equal(t, 5, rejected[1].Row)
+					assert.Equal(t, "Name", rejected[1].Column)
+					assert.Equal(t, 6, rejected[2].Row)
+					assert.Empty(t, rejected[2].Column)
+				}
+			},
+		},
+		{
+			name:      "Strict mode rejects the whole file",
+			mode:      schemas.ImportModeStrict,
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) { expectRollback(t, m) },
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.Error(t, err)
+				assert.False(t, response.Success)
+				assert.Equal(t, 0, response.Stats.SuccessCount)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+			tt.mockSetup(t, mockRepo)
+
+			service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+			response, err := service.ImportFromReader(
+				context.Background(),
+				claims,
+				"upload.csv",
+				strings.NewReader(mixedCSV),
+				schemas.ImportOptions{Mode: tt.mode},
+			)
+
+			tt.verify(t, response, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestValidateFromReader(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+
+	tests := []struct {
+		name    string
+		content string
+		verify  func(*testing.T, *schemas.ImportValidationResponseDTO)
+	}{
+		{
+			name:    "Valid file",
+			content: validCSV,
+			verify: func(t *testing.T, response *schemas.ImportValidationResponseDTO) {
+				assert.True(t, response.Valid)
+				assert.Equal(t, 2, response.ValidRows)
+				assert.Empty(t, response.Problems)
+			},
+		},
+		{
+			name: "Every problem is reported",
+			content: validCSV +
+				"Bad Age,bad@example.com,thirty,1 Oak St,555-0000,Sales,Clerk,oops,01/02/2010\n" +
+				",not-an-email,40,2 Oak St,555-0001,Sales,Clerk,30000,01/02/2010\n",
+			verify: func(t *testing.T, response *schemas.ImportValidationResponseDTO) {
+				assert.False(t, response.Valid)
+				assert.Equal(t, 4, response.TotalRows)
+				assert.Equal(t, 2, response.ValidRows)
+				assert.Equal(t, 2, response.InvalidRows)
+
+				columns := make([]string, 0, len(response.Problems))
+				for _, problem := range response.Problems {
+					columns = append(columns, problem.Column)
+				}
+				assert.Equal(t, []string{"Age", "Salary", "Name", "Email"}, columns)
+			},
+		},
+		{
+			name:    "Invalid header",
+			content: "Name,Mail\nJohn Doe,john@example.com\n",
+			verify: func(t *testing.T, response *schemas.ImportValidationResponseDTO) {
+				assert.False(t, response.Valid)
+				assert.Contains(t, response.Errors[0], "invalid CSV format")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+
+			service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+			response, err := service.ValidateFromReader(context.Background(), claims, "upload.csv", strings.NewReader(tt.content), schemas.ImportOptions{})
+
+			assert.NoError(t, err)
+			tt.verify(t, response)
+			mockRepo.AssertNotCalled(t, "BeginTaskImport", mock.Anything)
+		})
+	}
+}
+
+func TestImportWithMappingProfile(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+
+	hrProfile, err := schemas.NewMappingProfile("hr", map[string]schemas.ColumnMapping{
+		schemas.ColumnEmail:    {Aliases: []string{"E-mail"}},
+		schemas.ColumnHireDate: {Aliases: []string{"Start Date"}},
+		schemas.ColumnAddress:  {Optional: true, Default: "Head Office"},
+	})
+	assert.NoError(t, err)
+	profiles := schemas.MappingProfiles{Profiles: map[string]schemas.MappingProfile{"hr": hrProfile}}
+
+	hrCSV := "Start Date,Position,Name,E-mail,Phone Number,Age,Department,Salary\n" +
+		"15/01/2006,Manager,John Doe,john@example.com,555-1234,30,Sales,60000\n"
+
+	tests := []struct {
+		name      string
+		profile   string
+		mockSetup func(*testing.T, *MockTaskCommandRepository)
+		verify    func(*testing.T, *schemas.ImportTaskResponseDTO, error)
+	}{
+		{
+			name:    "Columns are matched by header name and alias",
+			profile: "hr",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) {
+				expectImport(t, m, mock.MatchedBy(func(tasks []schemas.TaskModel) bool {
+					return len(tasks) == 1 &&
+						tasks[0].Email == "john@example.com" &&
+						tasks[0].Address == "Head Office" &&
+						tasks[0].HireDate.Equal(time.Date(2006, 1, 15, 0, 0, 0, 0, time.UTC))
+				}))
+			},
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.NoError(t, err)
+				assert.True(t, response.Success)
+			},
+		},
+		{
+			name:      "Standard profile rejects the aliased headers",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) { expectRollback(t, m) },
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, response.Errors[0], "missing required columns for profile \"standard\": Email, Address, Hire Date")
+			},
+		},
+		{
+			name:      "Unknown profile",
+			profile:   "payroll",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) {},
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.ErrorIs(t, err, interfaces.ErrUnknownProfile)
+				assert.False(t, response.Success)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+			tt.mockSetup(t, mockRepo)
+
+			service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), profiles, testBatchSize)
+			response, err := service.ImportFromReader(
+				context.Background(),
+				claims,
+				"hr_export.csv",
+				strings.NewReader(hrCSV),
+				schemas.ImportOptions{Profile: tt.profile},
+			)
+
+			tt.verify(t, response, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestImportJSONFormats(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+
+	john := `{"name":"John Doe","email":"john@example.com","age":30,"address":"123 Elm St","phone_number":"555-1234",` +
+		`"department":"Sales","position":"Manager","salary":60000,"hire_date":"2006-01-15"}`
+	jane := `{"name":"Jane Dee","email":"jane@example.com","age":20,"address":"Feria Street","phone_number":"777-6542",` +
+		`"department":"IT","position":"Dev","salary":40000,"hire_date":"20/07/2007"}`
+	badAge := `{"name":"Bad Age","email":"bad@example.com","age":"thirty","address":"1 Oak St","phone_number":"555-0000",` +
+		`"department":"Sales","position":"Clerk","salary":30000,"hire_date":"2010-02-01"}`
+
+	tests := []struct {
+		name      string
+		fileName  string
+		content   string
+		opts      schemas.ImportOptions
+		mockSetup func(*testing.T, *MockTaskCommandRepository)
+		verify    func(*testing.T, *schemas.ImportTaskResponseDTO, error)
+	}{
+		{
+			name:     "JSON array detected by extension",
+			fileName: "tasks.json",
+			content:  "[" + john + "," + jane + "]",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) {
+				expectImport(t, m, tasksOwnedBy(claims.ClientName, claims.ClientID))
+			},
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, response.Stats.SuccessCount)
+			},
+		},
+		{
+			name:     "NDJSON by explicit format with a bad record",
+			fileName: "request body",
+			content:  john + "\n\n" + badAge + "\n" + jane + "\n",
+			opts:     schemas.ImportOptions{Format: schemas.ImportFormatNDJSON, Mode: schemas.ImportModePartial},
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) {
+				expectImport(t, m, mock.MatchedBy(func(tasks []schemas.TaskModel) bool {
+					return len(tasks) == 2
+				}))
+			},
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.NoError(t, err)
+				rejected := response.Stats.Files[0].RejectedRows
+				if assert.Len(t, rejected, 1) {
+					assert.Equal(t, schemas.RowErrorDTO{Row: 3, Column: "Age", Reason: "must be a whole number"}, rejected[0])
+				}
+			},
+		},
+		{
+			name:      "JSON that is not an array",
+			fileName:  "tasks.json",
+			content:   john,
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) { expectRollback(t, m) },
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, response.Errors[0], "expected an array of tasks")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+			tt.mockSetup(t, mockRepo)
+
+			service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+			response, err := service.ImportFromReader(context.Background(), claims, tt.fileName, strings.NewReader(tt.content), tt.opts)
+
+			tt.verify(t, response, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+// workbook builds an XLSX file with a summary sheet followed by a sheet of tasks
+func workbook(t *testing.T, sheet string, rows [][]interface{}) []byte {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", "Summary"); err != nil {
+		t.Fatalf("Failed to rename sheet: %v", err)
+	}
+	if _, err := f.NewSheet(sheet); err != nil {
+		t.Fatalf("Failed to add sheet: %v", err)
+	}
+	for i, row := range rows {
+		cell, _ := excelize.CoordinatesToCellName(1, i+1)
+		if err := f.SetSheetRow(sheet, cell, &row); err != nil {
+			t.Fatalf("Failed to write row: %v", err)
+		}
+	}
+
+	buffer, err := f.WriteToBuffer()
+	if err != nil {
+		t.Fatalf("Failed to write workbook: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestImportXLSX(t *testing.T) {
+	logger := logrus.New()
+	claims := jwt.ClientClaims{ClientName: "Client One Corp", ClientID: clientOneUUID}
+
+	content := workbook(t, "Tasks", [][]interface{}{
+		{"Name", "Email", "Age", "Address", "Phone Number", "Department", "Position", "Salary", "Hire Date"},
+		{"John Doe", "john@example.com", 30, "123 Elm St", "555-1234", "Sales", "Manager", 60000, 38732},
+		{},
+		{"Jane Dee", "jane@example.com", 20, "Feria Street", "777-6542", "IT", "Dev", 40000, "20/07/2007"},
+	})
+
+	tests := []struct {
+		name      string
+		sheet     string
+		mockSetup func(*testing.T, *MockTaskCommandRepository)
+		verify    func(*testing.T, *schemas.ImportTaskResponseDTO, error)
+	}{
+		{
+			name:  "Sheet selected by name",
+			sheet: "tasks",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) {
+				expectImport(t, m, mock.MatchedBy(func(tasks []schemas.TaskModel) bool {
+					return len(tasks) == 2 &&
+						tasks[0].HireDate.Equal(time.Date(2006, 1, 15, 0, 0, 0, 0, time.UTC)) &&
+						tasks[1].HireDate.Equal(time.Date(2007, 7, 20, 0, 0, 0, 0, time.UTC))
+				}))
+			},
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 2, response.Stats.SuccessCount)
+			},
+		},
+		{
+			name:  "Sheet selected by index",
+			sheet: "2",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) {
+				expectImport(t, m, mock.Anything)
+			},
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:      "First sheet has no task header",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) { expectRollback(t, m) },
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, response.Errors[0], "XLSX sheet \"Summary\" is empty")
+			},
+		},
+		{
+			name:      "Unknown sheet",
+			sheet:     "Payroll",
+			mockSetup: func(t *testing.T, m *MockTaskCommandRepository) { expectRollback(t, m) },
+			verify: func(t *testing.T, response *schemas.ImportTaskResponseDTO, err error) {
+				assert.Error(t, err)
+				assert.Contains(t, response.Errors[0], "available sheets: Summary, Tasks")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+			tt.mockSetup(t, mockRepo)
+
+			service := NewImportService(mockRepo, newMockLedger(), validation.NewDataValidator(logger), logger, t.TempDir(), schemas.MappingProfiles{}, testBatchSize)
+			response, err := service.ImportFromReader(
+				context.Background(),
+				claims,
+				"tasks.xlsx",
+				bytes.NewReader(content),
+				schemas.ImportOptions{Sheet: tt.sheet},
+			)
+
+			tt.verify(t, response, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
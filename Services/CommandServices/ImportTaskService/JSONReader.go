@@ -0,0 +1,202 @@
+package ImportTaskService
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+)
+
+// maxNDJSONLineSize bounds a single NDJSON record
+const maxNDJSONLineSize = 1024 * 1024
+
+// taskRecord is a task as sent in a JSON or NDJSON import. Fields use the keys of
+// TaskImportDTO, and hire_date accepts the same formats as the CSV column.
+type taskRecord struct {
+	Name        string  `json:"name"`
+	Email       string  `json:"email"`
+	Age         int     `json:"age"`
+	Address     string  `json:"address"`
+	PhoneNumber string  `json:"phone_number"`
+	Department  string  `json:"department"`
+	Position    string  `json:"position"`
+	Salary      float64 `json:"salary"`
+	HireDate    string  `json:"hire_date"`
+}
+
+// jsonEntrySource reads a JSON array of task records, numbering rows by record from 1
+type jsonEntrySource struct {
+	service *importService
+	decoder *json.Decoder
+	rowNum  int
+	done    bool
+}
+
+func (s *importService) newJSONEntrySource(source io.Reader) (entrySource, error) {
+	decoder := json.NewDecoder(source)
+
+	token, err := decoder.Token()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("JSON file is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSON format: %w", err)
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '[' {
+		return nil, fmt.Errorf("invalid JSON format: expected an array of tasks")
+	}
+
+	return &jsonEntrySource{
+		service: s,
+		decoder: decoder,
+	}, nil
+}
+
+func (j *jsonEntrySource) Next(ctx context.Context) (parsedRow, []schemas.RowErrorDTO, error) {
+	if err := ctx.Err(); err != nil {
+		return parsedRow{}, nil, fmt.Errorf("import cancelled: %w", err)
+	}
+
+	if j.done {
+		return parsedRow{}, nil, io.EOF
+	}
+
+	if !j.decoder.More() {
+		j.done = true
+		if _, err := j.decoder.Token(); err != nil {
+			return parsedRow{}, nil, fmt.Errorf("invalid JSON format: %w", err)
+		}
+		return parsedRow{}, nil, io.EOF
+	}
+
+	j.rowNum++
+	var raw json.RawMessage
+	if err := j.decoder.Decode(&raw); err != nil {
+		return parsedRow{}, nil, fmt.Errorf("invalid JSON format at record %d: %w", j.rowNum, err)
+	}
+
+	return j.service.parseRecord(raw, j.rowNum)
+}
+
+func (j *jsonEntrySource) Close() error {
+	return nil
+}
+
+// ndjsonEntrySource reads one task record per line, numbering rows by line
+type ndjsonEntrySource struct {
+	service *importService
+	scanner *bufio.Scanner
+	rowNum  int
+	records int
+}
+
+func (s *importService) newNDJSONEntrySource(source io.Reader) entrySource {
+	scanner := bufio.NewScanner(source)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxNDJSONLineSize)
+
+	return &ndjsonEntrySource{
+		service: s,
+		scanner: scanner,
+	}
+}
+
+func (n *ndjsonEntrySource) Next(ctx context.Context) (parsedRow, []schemas.RowErrorDTO, error) {
+	for n.scanner.Scan() {
+		if err := ctx.Err(); err != nil {
+			return parsedRow{}, nil, fmt.Errorf("import cancelled: %w", err)
+		}
+
+		n.rowNum++
+		line := bytes.TrimSpace(n.scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		n.records++
+		return n.service.parseRecord(line, n.rowNum)
+	}
+
+	if err := n.scanner.Err(); err != nil {
+		return parsedRow{}, nil, fmt.Errorf("failed to read NDJSON lines: %w", err)
+	}
+	if n.records == 0 {
+		return parsedRow{}, nil, fmt.Errorf("NDJSON file is empty")
+	}
+	return parsedRow{}, nil, io.EOF
+}
+
+func (n *ndjsonEntrySource) Close() error {
+	return nil
+}
+
+// parseRecord converts a JSON task record into an entry
+func (s *importService) parseRecord(raw []byte, rowNum int) (parsedRow, []schemas.RowErrorDTO, error) {
+	var record taskRecord
+	if err := json.Unmarshal(raw, &record); err != nil {
+		rowErr := recordError(err, rowNum)
+		s.logger.WithError(rowErr).Error("Failed to parse record")
+		return parsedRow{}, []schemas.RowErrorDTO{rowErr}, nil
+	}
+
+	hireDate, err := parseHireDate(record.HireDate)
+	if err != nil {
+		rowErr := schemas.RowErrorDTO{Row: rowNum, Column: recordHeader(schemas.ColumnHireDate), Reason: "date must be in DD/MM/YYYY format"}
+		s.logger.WithError(rowErr).Error("Failed to parse record")
+		return parsedRow{}, []schemas.RowErrorDTO{rowErr}, nil
+	}
+
+	return parsedRow{
+		number: rowNum,
+		entry: schemas.TaskImportDTO{
+			Name:        strings.TrimSpace(record.Name),
+			Email:       strings.TrimSpace(record.Email),
+			Age:         record.Age,
+			Address:     strings.TrimSpace(record.Address),
+			PhoneNumber: strings.TrimSpace(record.PhoneNumber),
+			Department:  strings.TrimSpace(record.Department),
+			Position:    strings.TrimSpace(record.Position),
+			Salary:      record.Salary,
+			HireDate:    hireDate,
+		},
+	}, nil, nil
+}
+
+// recordError describes why a JSON record could not be decoded, naming the field when known
+func recordError(err error, rowNum int) schemas.RowErrorDTO {
+	var typeErr *json.UnmarshalTypeError
+	if !errors.As(err, &typeErr) {
+		return schemas.RowErrorDTO{Row: rowNum, Reason: fmt.Sprintf("invalid JSON: %v", err)}
+	}
+
+	if typeErr.Field == "" {
+		return schemas.RowErrorDTO{Row: rowNum, Reason: "must be a JSON object"}
+	}
+
+	reason := "must be a string"
+	switch typeErr.Type.Kind() {
+	case reflect.Int:
+		reason = "must be a whole number"
+	case reflect.Float64:
+		reason = "must be a number"
+	}
+
+	return schemas.RowErrorDTO{Row: rowNum, Column: recordHeader(typeErr.Field), Reason: reason}
+}
+
+// recordHeader reports a JSON field under its CSV column name, so every format
+// describes problems the same way
+func recordHeader(key string) string {
+	for _, column := range schemas.StandardMappingProfile().Columns {
+		if column.Key == key {
+			return column.Header
+		}
+	}
+	return key
+}
@@ -0,0 +1,97 @@
+package ImportTaskService
+
+import (
+	"context"
+
+	"taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+)
+
+// taskBatcher buffers the tasks of one file and writes them in fixed-size batches inside
+// the file's import transaction
+type taskBatcher struct {
+	size   int
+	batch  []schemas.TaskModel
+	writer interfaces.TaskBatchWriter
+	counts schemas.ImportWriteCounts
+}
+
+// beginTaskBatcher opens the import transaction of one file
+func beginTaskBatcher(ctx context.Context, repo interfaces.TaskCommandRepository, size int, upsert bool) (*taskBatcher, error) {
+	begin := repo.BeginTaskImport
+	if upsert {
+		begin = repo.BeginTaskUpsert
+	}
+	writer, err := begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if size < 1 {
+		size = 1
+	}
+	return &taskBatcher{
+		size:   size,
+		batch:  make([]schemas.TaskModel, 0, size),
+		writer: writer,
+	}, nil
+}
+
+// LockFile waits for any other import of the same file content by the client to finish
+// and returns the latest successful import of that content. The lock is held until the
+// import is committed or rolled back.
+func (b *taskBatcher) LockFile(ctx context.Context, clientID string, fileHash string) (*schemas.ImportLedgerModel, error) {
+	return b.writer.LockFile(ctx, clientID, fileHash)
+}
+
+// Add buffers a task, returning how many tasks were written if the batch filled up
+func (b *taskBatcher) Add(ctx context.Context, task schemas.TaskModel) (int, error) {
+	b.batch = append(b.batch, task)
+	if len(b.batch) < b.size {
+		return 0, nil
+	}
+	return b.Flush(ctx)
+}
+
+// Flush writes any buffered tasks, returning how many were written
+func (b *taskBatcher) Flush(ctx context.Context) (int, error) {
+	if len(b.batch) == 0 {
+		return 0, nil
+	}
+
+	counts, err := b.writer.WriteBatch(ctx, b.batch)
+	if err != nil {
+		return 0, err
+	}
+
+	written := len(b.batch)
+	b.counts.Add(counts)
+	b.batch = b.batch[:0]
+	return written, nil
+}
+
+// Commit records the import in the ledger and commits the entry together with every
+// written task
+func (b *taskBatcher) Commit(ctx context.Context, entry *schemas.ImportLedgerModel) error {
+	if err := b.writer.RecordImport(ctx, entry); err != nil {
+		return err
+	}
+	if err := b.writer.Commit(); err != nil {
+		return err
+	}
+	b.writer = nil
+	return nil
+}
+
+// Rollback discards everything written so far; it is a no-op after Commit or Rollback
+func (b *taskBatcher) Rollback() {
+	if b.writer != nil {
+		b.writer.Rollback()
+		b.writer = nil
+	}
+}
+
+// Counts returns how many tasks were inserted, updated or left unchanged
+func (b *taskBatcher) Counts() schemas.ImportWriteCounts {
+	return b.counts
+}
@@ -0,0 +1,166 @@
+package ImportTaskService
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxEntrySource reads one worksheet of an Excel workbook. The first row is matched
+// against the requested mapping profile like a CSV header.
+type xlsxEntrySource struct {
+	service  *importService
+	workbook *excelize.File
+	rows     *excelize.Rows
+	layout   *columnLayout
+	width    int
+	rowNum   int
+}
+
+func (s *importService) newXLSXEntrySource(source io.Reader, opts schemas.ImportOptions) (entrySource, error) {
+	workbook, err := excelize.OpenReader(source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open XLSX workbook: %w", err)
+	}
+
+	xlsx := &xlsxEntrySource{
+		service:  s,
+		workbook: workbook,
+	}
+	if err := xlsx.readHeader(opts); err != nil {
+		xlsx.Close()
+		return nil, err
+	}
+
+	return xlsx, nil
+}
+
+func (x *xlsxEntrySource) readHeader(opts schemas.ImportOptions) error {
+	sheet, err := selectSheet(x.workbook.GetSheetList(), opts.Sheet)
+	if err != nil {
+		return fmt.Errorf("invalid XLSX format: %w", err)
+	}
+
+	x.rows, err = x.workbook.Rows(sheet)
+	if err != nil {
+		return fmt.Errorf("failed to read XLSX rows: %w", err)
+	}
+
+	if !x.rows.Next() {
+		return fmt.Errorf("XLSX sheet %q is empty", sheet)
+	}
+	header, err := x.rows.Columns()
+	if err != nil {
+		return fmt.Errorf("failed to read XLSX rows: %w", err)
+	}
+	x.rowNum = 1
+
+	profile, err := x.service.mappingProfile(opts.Profile)
+	if err != nil {
+		return err
+	}
+
+	x.layout, err = newColumnLayout(profile, header)
+	if err != nil {
+		return fmt.Errorf("invalid XLSX format: %w", err)
+	}
+	x.width = len(header)
+
+	x.service.logger.WithField("sheet", sheet).Info("Reading XLSX sheet")
+	return nil
+}
+
+func (x *xlsxEntrySource) Next(ctx context.Context) (parsedRow, []schemas.RowErrorDTO, error) {
+	for x.rows.Next() {
+		if err := ctx.Err(); err != nil {
+			return parsedRow{}, nil, fmt.Errorf("import cancelled: %w", err)
+		}
+
+		x.rowNum++
+		// Raw values keep dates as Excel serial numbers instead of locale-formatted text
+		row, err := x.rows.Columns(excelize.Options{RawCellValue: true})
+		if err != nil {
+			return parsedRow{}, nil, fmt.Errorf("failed to read XLSX row %d: %w", x.rowNum, err)
+		}
+
+		row = normalizeSheetRow(x.layout, row, x.width)
+		if row == nil {
+			continue
+		}
+
+		entry, rowErrs := x.service.parseTableRow(x.layout, row, x.rowNum)
+		return entry, rowErrs, nil
+	}
+
+	if err := x.rows.Error(); err != nil {
+		return parsedRow{}, nil, fmt.Errorf("failed to read XLSX rows: %w", err)
+	}
+	return parsedRow{}, nil, io.EOF
+}
+
+func (x *xlsxEntrySource) Close() error {
+	if x.rows != nil {
+		x.rows.Close()
+	}
+	return x.workbook.Close()
+}
+
+// selectSheet picks a worksheet by name or 1-based index, defaulting to the first one
+func selectSheet(sheets []string, requested string) (string, error) {
+	if len(sheets) == 0 {
+		return "", fmt.Errorf("workbook has no sheets")
+	}
+
+	requested = strings.TrimSpace(requested)
+	if requested == "" {
+		return sheets[0], nil
+	}
+
+	for _, sheet := range sheets {
+		if strings.EqualFold(sheet, requested) {
+			return sheet, nil
+		}
+	}
+
+	if index, err := strconv.Atoi(requested); err == nil && index >= 1 && index <= len(sheets) {
+		return sheets[index-1], nil
+	}
+
+	return "", fmt.Errorf("sheet %q not found, available sheets: %s", requested, strings.Join(sheets, ", "))
+}
+
+// normalizeSheetRow pads a worksheet row to the header width, since trailing empty cells
+// are not stored, and turns Excel serial dates in the hire date column into ISO dates.
+// Rows with no values at all are returned as nil.
+func normalizeSheetRow(layout *columnLayout, row []string, width int) []string {
+	blank := true
+	for _, cell := range row {
+		if strings.TrimSpace(cell) != "" {
+			blank = false
+			break
+		}
+	}
+	if blank {
+		return nil
+	}
+
+	for len(row) < width {
+		row = append(row, "")
+	}
+
+	if position, ok := layout.position(schemas.ColumnHireDate); ok {
+		if serial, err := strconv.ParseFloat(strings.TrimSpace(row[position]), 64); err == nil {
+			if hireDate, err := excelize.ExcelDateToTime(serial, false); err == nil {
+				row[position] = hireDate.Format("2006-01-02")
+			}
+		}
+	}
+
+	return row
+}
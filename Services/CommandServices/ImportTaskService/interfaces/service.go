@@ -1,9 +1,44 @@
 package interfaces
 
 import (
+	"context"
+	"errors"
+	"io"
+
+	"taskmanager/RequestControllers/httpSetup/jwt"
 	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
 )
 
+// ErrUnknownProfile is returned when an import requests a mapping profile that is not configured
+var ErrUnknownProfile = errors.New("unknown mapping profile")
+
+// ErrDuplicateFile is returned when a file was already imported successfully and the import is not forced
+var ErrDuplicateFile = errors.New("file already imported")
+
 type ImportService interface {
-	Import() (*schemas.ImportTaskResponseDTO, error)
+	// Import reads the import directory and stores every entry under the calling client
+	Import(ctx context.Context, claims jwt.ClientClaims, opts schemas.ImportOptions) (*schemas.ImportTaskResponseDTO, error)
+
+	// ImportFromReader imports CSV, XLSX, JSON or NDJSON content supplied by the caller, such as an
+	// uploaded file. The format is opts.Format, or the one named by the extension of fileName when unset.
+	ImportFromReader(
+		ctx context.Context,
+		claims jwt.ClientClaims,
+		fileName string,
+		reader io.Reader,
+		opts schemas.ImportOptions,
+	) (*schemas.ImportTaskResponseDTO, error)
+
+	// ValidateFromReader checks content against every import rule without storing anything. The format
+	// is chosen as for ImportFromReader.
+	ValidateFromReader(
+		ctx context.Context,
+		claims jwt.ClientClaims,
+		fileName string,
+		reader io.Reader,
+		opts schemas.ImportOptions,
+	) (*schemas.ImportValidationResponseDTO, error)
+
+	// History lists the files imported by the calling client, newest first
+	History(ctx context.Context, claims jwt.ClientClaims, limit int) (*schemas.ImportHistoryResponseDTO, error)
 }
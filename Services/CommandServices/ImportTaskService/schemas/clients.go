@@ -0,0 +1,62 @@
+package schemas
+
+import "time"
+
+// ClientModel represents an API client and its hashed secret
+type ClientModel struct {
+	ClientID        string    `db:"client_id"`
+	ClientName      string    `db:"client_name"`
+	SecretHash      string    `db:"secret_hash"`
+	IsActive        bool      `db:"is_active"`
+	Scopes          []string  `db:"scopes"`
+	SecretRotatedAt time.Time `db:"secret_rotated_at"`
+	CreatedAt       time.Time `db:"created_at"`
+	UpdatedAt       time.Time `db:"updated_at"`
+}
+
+// RegisterClientRequestDTO is the body of a client registration
+type RegisterClientRequestDTO struct {
+	ClientName string `json:"client_name" binding:"required" example:"Client One Corp"`
+	// ClientID registers an existing tenant; a new UUID is generated when it is empty
+	ClientID string `json:"client_id,omitempty" binding:"omitempty,uuid" example:"9ebcc92c-e186-41b3-834b-f75ab3f110ae"`
+	// Scopes granted to the client; tasks:read, tasks:write and import:run when empty
+	Scopes []string `json:"scopes,omitempty" example:"tasks:read,import:run"`
+}
+
+// UpdateClientScopesRequestDTO is the body of a client scopes change
+type UpdateClientScopesRequestDTO struct {
+	Scopes []string `json:"scopes" binding:"required" example:"tasks:read"`
+}
+
+// ClientDTO represents a client without its secret
+type ClientDTO struct {
+	ClientID        string    `json:"client_id"`
+	ClientName      string    `json:"client_name"`
+	IsActive        bool      `json:"is_active"`
+	Scopes          []string  `json:"scopes"`
+	SecretRotatedAt time.Time `json:"secret_rotated_at"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+}
+
+// ClientResponseDTO represents the response for client commands. ClientSecret is only
+// returned when a secret is issued and cannot be retrieved again.
+type ClientResponseDTO struct {
+	Success      bool       `json:"success"`
+	Message      string     `json:"message"`
+	Client       *ClientDTO `json:"client,omitempty"`
+	ClientSecret string     `json:"client_secret,omitempty"`
+}
+
+// MapToDTO converts a ClientModel to a ClientDTO
+func (m *ClientModel) MapToDTO() *ClientDTO {
+	return &ClientDTO{
+		ClientID:        m.ClientID,
+		ClientName:      m.ClientName,
+		IsActive:        m.IsActive,
+		Scopes:          m.Scopes,
+		SecretRotatedAt: m.SecretRotatedAt,
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
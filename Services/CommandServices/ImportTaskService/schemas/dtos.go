@@ -1,6 +1,71 @@
 package schemas
 
-import "time"
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Import modes
+const (
+	// ImportModeStrict rejects the whole file when any row is invalid
+	ImportModeStrict = "strict"
+	// ImportModePartial inserts the valid rows and reports the rejected ones
+	ImportModePartial = "partial"
+)
+
+// Import formats
+const (
+	ImportFormatCSV    = "csv"
+	ImportFormatJSON   = "json"
+	ImportFormatNDJSON = "ndjson"
+	ImportFormatXLSX   = "xlsx"
+)
+
+// importFormatExtensions maps import file extensions to their format
+var importFormatExtensions = map[string]string{
+	".csv":    ImportFormatCSV,
+	".json":   ImportFormatJSON,
+	".ndjson": ImportFormatNDJSON,
+	".jsonl":  ImportFormatNDJSON,
+	".xlsx":   ImportFormatXLSX,
+}
+
+// ImportFormatFromFileName detects the format of an import file from its extension,
+// defaulting to CSV
+func ImportFormatFromFileName(fileName string) string {
+	if format, ok := importFormatExtensions[strings.ToLower(filepath.Ext(fileName))]; ok {
+		return format
+	}
+	return ImportFormatCSV
+}
+
+// IsImportFileName reports whether a file in the import directory should be imported,
+// skipping the lock files Excel leaves next to open workbooks
+func IsImportFileName(fileName string) bool {
+	if strings.HasPrefix(fileName, "~$") {
+		return false
+	}
+	_, ok := importFormatExtensions[strings.ToLower(filepath.Ext(fileName))]
+	return ok
+}
+
+// ImportFormatFromContentType detects the format of a raw request body
+func ImportFormatFromContentType(contentType string) (string, bool) {
+	switch contentType {
+	case "text/csv":
+		return ImportFormatCSV, true
+	case "application/json":
+		return ImportFormatJSON, true
+	case "application/x-ndjson", "application/jsonl":
+		return ImportFormatNDJSON, true
+	case "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":
+		return ImportFormatXLSX, true
+	default:
+		return "", false
+	}
+}
 
 // ImportTaskRequestDTO represents the incoming request for task import
 type ImportTaskRequestDTO struct {
@@ -19,12 +84,105 @@ type ImportTaskResponseDTO struct {
 
 // ImportStatsDTO represents statistics about the import process
 type ImportStatsDTO struct {
-	TotalProcessed int       `json:"total_processed"`
-	SuccessCount   int       `json:"success_count"`
-	ErrorCount     int       `json:"error_count"`
-	StartTime      time.Time `json:"start_time"`
-	EndTime        time.Time `json:"end_time"`
-	DurationMS     int64     `json:"duration_ms"`
+	TotalProcessed int                   `json:"total_processed"`
+	SuccessCount   int                   `json:"success_count"`
+	ErrorCount     int                   `json:"error_count"`
+	StartTime      time.Time             `json:"start_time"`
+	EndTime        time.Time             `json:"end_time"`
+	DurationMS     int64                 `json:"duration_ms"`
+	Files          []FileImportResultDTO `json:"files,omitempty"`
+	ImportWriteCounts
+}
+
+// FileImportResultDTO represents the outcome of importing a single file
+type FileImportResultDTO struct {
+	FileName            string        `json:"file_name"`
+	Success             bool          `json:"success"`
+	TotalEntries        int           `json:"total_entries"`
+	SuccessCount        int           `json:"success_count"`
+	ErrorCount          int           `json:"error_count"`
+	Errors              []string      `json:"errors,omitempty"`
+	RejectedRows        []RowErrorDTO `json:"rejected_rows,omitempty"`
+	RejectedRowsOmitted int           `json:"rejected_rows_omitted,omitempty"`
+	ArchivedTo          string        `json:"archived_to,omitempty"`
+	ImportWriteCounts
+}
+
+// ImportWriteCounts reports what happened to the rows an import wrote. Without upsert
+// every written row is inserted.
+type ImportWriteCounts struct {
+	Inserted  int `json:"inserted"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Total returns the number of rows written
+func (c ImportWriteCounts) Total() int {
+	return c.Inserted + c.Updated + c.Unchanged
+}
+
+// Add accumulates the counts of another write
+func (c *ImportWriteCounts) Add(other ImportWriteCounts) {
+	c.Inserted += other.Inserted
+	c.Updated += other.Updated
+	c.Unchanged += other.Unchanged
+}
+
+// AddRejectedRow counts a rejected row and records its problems, keeping at most
+// limit problems in detail
+func (r *FileImportResultDTO) AddRejectedRow(problems []RowErrorDTO, limit int) {
+	r.ErrorCount++
+
+	room := limit - len(r.RejectedRows)
+	if room < 0 {
+		room = 0
+	}
+	if len(problems) > room {
+		r.RejectedRowsOmitted += len(problems) - room
+		problems = problems[:room]
+	}
+	r.RejectedRows = append(r.RejectedRows, problems...)
+}
+
+// ImportValidationResponseDTO reports every problem found in a file without importing it
+type ImportValidationResponseDTO struct {
+	Valid       bool          `json:"valid"`
+	Message     string        `json:"message"`
+	FileName    string        `json:"file_name"`
+	TotalRows   int           `json:"total_rows"`
+	ValidRows   int           `json:"valid_rows"`
+	InvalidRows int           `json:"invalid_rows"`
+	Errors      []string      `json:"errors,omitempty"`
+	Problems    []RowErrorDTO `json:"problems,omitempty"`
+}
+
+// RowErrorDTO describes why a row was rejected
+type RowErrorDTO struct {
+	Row    int    `json:"row"`
+	Column string `json:"column,omitempty"`
+	Reason string `json:"reason"`
+}
+
+func (e RowErrorDTO) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Reason)
+	}
+	return fmt.Sprintf("invalid %s at row %d: %s", strings.ToLower(e.Column), e.Row, e.Reason)
+}
+
+// AddFile adds the outcome of one file to the overall statistics
+func (s *ImportStatsDTO) AddFile(result FileImportResultDTO) {
+	s.Files = append(s.Files, result)
+	s.TotalProcessed += result.TotalEntries
+	s.SuccessCount += result.SuccessCount
+	s.ErrorCount += result.ErrorCount
+	s.ImportWriteCounts.Add(result.ImportWriteCounts)
+}
+
+// Finish records the end time and duration of the import
+func (s *ImportStatsDTO) Finish() {
+	s.EndTime = time.Now()
+	s.DurationMS = s.EndTime.Sub(s.StartTime).Milliseconds()
 }
 
 // TaskImportDTO represents a single row of task data to be imported
@@ -39,3 +197,55 @@ type TaskImportDTO struct {
 	Salary      float64   `json:"salary" validate:"required,gte=0"`
 	HireDate    time.Time `json:"hire_date" validate:"required"`
 }
+
+// ImportOptions controls a single import run
+type ImportOptions struct {
+	// Mode is ImportModeStrict or ImportModePartial; empty means strict
+	Mode string `json:"mode,omitempty"`
+	// Profile names the column mapping profile; empty means the configured default
+	Profile string `json:"profile,omitempty"`
+	// Format is one of the ImportFormat constants; empty means
+	// it is detected from the file name
+	Format string `json:"format,omitempty"`
+	// Sheet selects an XLSX worksheet by name or 1-based index; empty means the first sheet
+	Sheet string `json:"sheet,omitempty"`
+	// Upsert updates the existing task with the same client and email instead of
+	// inserting a duplicate
+	Upsert bool `json:"upsert,omitempty"`
+	// Force imports a file even if the same content was already imported successfully
+	Force bool `json:"force,omitempty"`
+	// OnProgress, when set, is called as rows move through the import
+	OnProgress func(progress ImportProgress) `json:"-"`
+}
+
+// ParseImportMode validates a requested import mode, defaulting to strict
+func ParseImportMode(mode string) (string, error) {
+	switch mode {
+	case "", ImportModeStrict:
+		return ImportModeStrict, nil
+	case ImportModePartial:
+		return ImportModePartial, nil
+	default:
+		return "", fmt.Errorf("invalid import mode %q: must be %s or %s", mode, ImportModeStrict, ImportModePartial)
+	}
+}
+
+// IsPartial reports whether valid rows should be kept when other rows are rejected
+func (o ImportOptions) IsPartial() bool {
+	return o.Mode == ImportModePartial
+}
+
+// ReportProgress forwards progress to OnProgress when it is set
+func (o ImportOptions) ReportProgress(progress ImportProgress) {
+	if o.OnProgress != nil {
+		o.OnProgress(progress)
+	}
+}
+
+// ImportProgress represents how far an import has advanced
+type ImportProgress struct {
+	RowsParsed    int `json:"rows_parsed"`
+	RowsValidated int `json:"rows_validated"`
+	RowsInserted  int `json:"rows_inserted"`
+	RowsRejected  int `json:"rows_rejected"`
+}
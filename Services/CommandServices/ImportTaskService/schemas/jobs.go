@@ -0,0 +1,77 @@
+package schemas
+
+import "time"
+
+// Import job statuses
+const (
+	ImportJobQueued    = "QUEUED"
+	ImportJobRunning   = "RUNNING"
+	ImportJobCompleted = "COMPLETED"
+	ImportJobFailed    = "FAILED"
+	ImportJobCancelled = "CANCELLED"
+)
+
+// ImportJobModel represents an asynchronous import job stored in import_jobs
+type ImportJobModel struct {
+	ID            string     `db:"id"`
+	ClientName    string     `db:"client_name"`
+	ClientID      string     `db:"client_id"`
+	Source        string     `db:"source"`
+	Status        string     `db:"status"`
+	RowsParsed    int        `db:"rows_parsed"`
+	RowsValidated int        `db:"rows_validated"`
+	RowsInserted  int        `db:"rows_inserted"`
+	RowsRejected  int        `db:"rows_rejected"`
+	ErrorMessage  string     `db:"error_message"`
+	CreatedAt     time.Time  `db:"created_at"`
+	StartedAt     *time.Time `db:"started_at"`
+	FinishedAt    *time.Time `db:"finished_at"`
+}
+
+// IsFinished reports whether the job has reached a terminal status
+func (j *ImportJobModel) IsFinished() bool {
+	switch j.Status {
+	case ImportJobCompleted, ImportJobFailed, ImportJobCancelled:
+		return true
+	default:
+		return false
+	}
+}
+
+// ImportJobDTO represents the state of an import job returned to the client
+type ImportJobDTO struct {
+	ID           string         `json:"id"`
+	Source       string         `json:"source"`
+	Status       string         `json:"status"`
+	Progress     ImportProgress `json:"progress"`
+	ErrorMessage string         `json:"error_message,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	StartedAt    *time.Time     `json:"started_at,omitempty"`
+	FinishedAt   *time.Time     `json:"finished_at,omitempty"`
+}
+
+// ImportJobResponseDTO represents the response for import job commands
+type ImportJobResponseDTO struct {
+	Success bool          `json:"success"`
+	Message string        `json:"message"`
+	Job     *ImportJobDTO `json:"job,omitempty"`
+}
+
+// MapToDTO converts an ImportJobModel to an ImportJobDTO
+func (j *ImportJobModel) MapToDTO() *ImportJobDTO {
+	return &ImportJobDTO{
+		ID:     j.ID,
+		Source: j.Source,
+		Status: j.Status,
+		Progress: ImportProgress{
+			RowsParsed:    j.RowsParsed,
+			RowsValidated: j.RowsValidated,
+			RowsInserted:  j.RowsInserted,
+			RowsRejected:  j.RowsRejected,
+		},
+		ErrorMessage: j.ErrorMessage,
+		CreatedAt:    j.CreatedAt,
+		StartedAt:    j.StartedAt,
+		FinishedAt:   j.FinishedAt,
+	}
+}
@@ -0,0 +1,118 @@
+package schemas
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Import ledger outcomes
+const (
+	ImportLedgerSucceeded = "SUCCEEDED"
+	ImportLedgerFailed    = "FAILED"
+	ImportLedgerDuplicate = "DUPLICATE"
+)
+
+// Import history page sizes
+const (
+	DefaultImportHistoryLimit = 50
+	MaxImportHistoryLimit     = 500
+)
+
+// ImportLedgerModel records one imported file, identified by the SHA-256 of its content
+type ImportLedgerModel struct {
+	ID           string    `db:"id"`
+	ClientName   string    `db:"client_name"`
+	ClientID     string    `db:"client_id"`
+	FileName     string    `db:"file_name"`
+	FileHash     string    `db:"file_hash"`
+	Status       string    `db:"status"`
+	Forced       bool      `db:"forced"`
+	TotalRows    int       `db:"total_rows"`
+	ImportedRows int       `db:"imported_rows"`
+	RejectedRows int       `db:"rejected_rows"`
+	Inserted     int       `db:"inserted_rows"`
+	Updated      int       `db:"updated_rows"`
+	Unchanged    int       `db:"unchanged_rows"`
+	ErrorMessage string    `db:"error_message"`
+	StartedAt    time.Time `db:"started_at"`
+	FinishedAt   time.Time `db:"finished_at"`
+}
+
+// RecordResult copies the outcome of a file import onto the ledger entry
+func (m *ImportLedgerModel) RecordResult(result *FileImportResultDTO, importErr error) {
+	m.Status = ImportLedgerSucceeded
+	if !result.Success {
+		m.Status = ImportLedgerFailed
+	}
+	if importErr != nil {
+		m.ErrorMessage = importErr.Error()
+	}
+
+	m.TotalRows = result.TotalEntries
+	m.ImportedRows = result.SuccessCount
+	m.RejectedRows = result.ErrorCount
+	m.Inserted = result.Inserted
+	m.Updated = result.Updated
+	m.Unchanged = result.Unchanged
+	m.FinishedAt = time.Now()
+}
+
+// ImportLedgerEntryDTO represents a ledger entry returned to the client
+type ImportLedgerEntryDTO struct {
+	ID           string    `json:"id"`
+	FileName     string    `json:"file_name"`
+	FileHash     string    `json:"file_hash"`
+	Status       string    `json:"status"`
+	Forced       bool      `json:"forced"`
+	TotalRows    int       `json:"total_rows"`
+	ImportedRows int       `json:"imported_rows"`
+	RejectedRows int       `json:"rejected_rows"`
+	ErrorMessage string    `json:"error_message,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	FinishedAt   time.Time `json:"finished_at"`
+	ImportWriteCounts
+}
+
+// ImportHistoryResponseDTO lists the files a client has imported, newest first
+type ImportHistoryResponseDTO struct {
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Imports []ImportLedgerEntryDTO `json:"imports"`
+}
+
+// MapToDTO converts an ImportLedgerModel to an ImportLedgerEntryDTO
+func (m *ImportLedgerModel) MapToDTO() ImportLedgerEntryDTO {
+	return ImportLedgerEntryDTO{
+		ID:           m.ID,
+		FileName:     m.FileName,
+		FileHash:     m.FileHash,
+		Status:       m.Status,
+		Forced:       m.Forced,
+		TotalRows:    m.TotalRows,
+		ImportedRows: m.ImportedRows,
+		RejectedRows: m.RejectedRows,
+		ErrorMessage: m.ErrorMessage,
+		StartedAt:    m.StartedAt,
+		FinishedAt:   m.FinishedAt,
+		ImportWriteCounts: ImportWriteCounts{
+			Inserted:  m.Inserted,
+			Updated:   m.Updated,
+			Unchanged: m.Unchanged,
+		},
+	}
+}
+
+// ParseImportHistoryLimit validates a requested history page size, defaulting to
+// DefaultImportHistoryLimit
+func ParseImportHistoryLimit(value string) (int, error) {
+	if value == "" {
+		return DefaultImportHistoryLimit, nil
+	}
+
+	limit, err := strconv.Atoi(value)
+	if err != nil || limit < 1 || limit > MaxImportHistoryLimit {
+		return 0, fmt.Errorf("invalid limit %q: must be between 1 and %d", value, MaxImportHistoryLimit)
+	}
+	return limit, nil
+}
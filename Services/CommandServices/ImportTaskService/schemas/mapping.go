@@ -0,0 +1,138 @@
+package schemas
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// StandardProfileName names the built-in mapping profile that matches the documented CSV layout
+const StandardProfileName = "standard"
+
+// Column keys identify task fields in mapping profiles
+const (
+	ColumnName        = "name"
+	ColumnEmail       = "email"
+	ColumnAge         = "age"
+	ColumnAddress     = "address"
+	ColumnPhoneNumber = "phone_number"
+	ColumnDepartment  = "department"
+	ColumnPosition    = "position"
+	ColumnSalary      = "salary"
+	ColumnHireDate    = "hire_date"
+)
+
+// ColumnMapping describes how a task field is found in an import file
+type ColumnMapping struct {
+	// Key is the field the column fills, such as ColumnHireDate
+	Key string
+	// Header is the standard column name, which is always accepted and used in error reports
+	Header string
+	// Aliases are other header names accepted for the column
+	Aliases []string
+	// Optional columns may be missing from the file, in which case Default is used
+	Optional bool
+	Default  string
+}
+
+// Matches reports whether a file header names this column
+func (c ColumnMapping) Matches(header string) bool {
+	header = strings.TrimSpace(header)
+	if strings.EqualFold(header, c.Header) {
+		return true
+	}
+	for _, alias := range c.Aliases {
+		if strings.EqualFold(header, strings.TrimSpace(alias)) {
+			return true
+		}
+	}
+	return false
+}
+
+// MappingProfile maps the headers of an import file to task fields
+type MappingProfile struct {
+	Name    string
+	Columns []ColumnMapping
+}
+
+// StandardMappingProfile returns the nine required columns of the documented CSV layout
+func StandardMappingProfile() MappingProfile {
+	return MappingProfile{
+		Name: StandardProfileName,
+		Columns: []ColumnMapping{
+			{Key: ColumnName, Header: "Name"},
+			{Key: ColumnEmail, Header: "Email"},
+			{Key: ColumnAge, Header: "Age"},
+			{Key: ColumnAddress, Header: "Address"},
+			{Key: ColumnPhoneNumber, Header: "Phone Number"},
+			{Key: ColumnDepartment, Header: "Department"},
+			{Key: ColumnPosition, Header: "Position"},
+			{Key: ColumnSalary, Header: "Salary"},
+			{Key: ColumnHireDate, Header: "Hire Date"},
+		},
+	}
+}
+
+// NewMappingProfile builds a profile from the standard layout, replacing the aliases,
+// optionality and default of the columns named in overrides by their key
+func NewMappingProfile(name string, overrides map[string]ColumnMapping) (MappingProfile, error) {
+	profile := StandardMappingProfile()
+	profile.Name = name
+
+	index := make(map[string]int, len(profile.Columns))
+	for i, column := range profile.Columns {
+		index[column.Key] = i
+	}
+
+	for key, override := range overrides {
+		i, ok := index[key]
+		if !ok {
+			return MappingProfile{}, fmt.Errorf("profile %q maps unknown column %q", name, key)
+		}
+
+		column := profile.Columns[i]
+		column.Aliases = override.Aliases
+		column.Optional = override.Optional
+		column.Default = override.Default
+		profile.Columns[i] = column
+	}
+
+	return profile, nil
+}
+
+// MappingProfiles holds the configured profiles and the one used when none is requested
+type MappingProfiles struct {
+	Default  string
+	Profiles map[string]MappingProfile
+}
+
+// Lookup returns the named profile, falling back to the default profile for an empty name
+func (p MappingProfiles) Lookup(name string) (MappingProfile, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+	if name == "" {
+		name = strings.ToLower(p.Default)
+	}
+	if name == "" {
+		name = StandardProfileName
+	}
+
+	if profile, ok := p.Profiles[name]; ok {
+		return profile, true
+	}
+	if name == StandardProfileName {
+		return StandardMappingProfile(), true
+	}
+	return MappingProfile{}, false
+}
+
+// Names lists the profiles that can be requested
+func (p MappingProfiles) Names() []string {
+	names := []string{StandardProfileName}
+	for name := range p.Profiles {
+		if name != StandardProfileName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names[1:])
+	return names
+}
@@ -33,4 +33,10 @@ func (m *TaskModel) MapFromDTO(dto TaskImportDTO) {
 	m.Salary = dto.Salary
 	m.HireDate = dto.HireDate
 	m.IsActive = true // default value for new tasks
-}
\ No newline at end of file
+}
+
+// AssignClient stamps the owning client onto the task
+func (m *TaskModel) AssignClient(clientName, clientID string) {
+	m.ClientName = clientName
+	m.ClientID = clientID
+}
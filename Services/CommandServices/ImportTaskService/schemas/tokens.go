@@ -0,0 +1,29 @@
+package schemas
+
+import "time"
+
+// RefreshTokenModel represents a stored refresh token. Only the hash of the token is kept.
+type RefreshTokenModel struct {
+	ID string `db:"id"`
+	// FamilyID is shared by a refresh token and every token it was rotated into
+	FamilyID   string     `db:"family_id"`
+	ClientName string     `db:"client_name"`
+	ClientID   string     `db:"client_id"`
+	TokenHash  string     `db:"token_hash"`
+	ExpiresAt  time.Time  `db:"expires_at"`
+	UsedAt     *time.Time `db:"used_at"`
+	RevokedAt  *time.Time `db:"revoked_at"`
+	CreatedAt  time.Time  `db:"created_at"`
+	// AccessTokenID and AccessExpiresAt identify the access token issued with the refresh
+	// token, which is revoked together with its family
+	AccessTokenID   string    `db:"access_jti"`
+	AccessExpiresAt time.Time `db:"access_expires_at"`
+}
+
+// TokenPairDTO is an access token with the refresh token that renews it
+type TokenPairDTO struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	// ExpiresIn is the lifetime of the access token in seconds
+	ExpiresIn int64 `json:"expires_in"`
+}
@@ -21,39 +21,39 @@ func NewDataValidator(logger *logrus.Logger) interfaces.Validator {
 }
 
 func (v *dataValidator) ValidateEntry(entry *schemas.TaskImportDTO) error {
-	if err := v.checkName(entry.Name); err != nil {
-		return fmt.Errorf("invalid name: %w", err)
+	if errs := v.ValidateEntryFields(entry); len(errs) > 0 {
+		return fmt.Errorf("invalid %s: %s", strings.ToLower(errs[0].Field), errs[0].Message)
 	}
 
-	if err := v.checkEmail(entry.Email); err != nil {
-		return fmt.Errorf("invalid email: %w", err)
-	}
-
-	if err := v.checkAge(entry.Age); err != nil {
-		return fmt.Errorf("invalid age: %w", err)
-	}
-
-	if err := v.checkAddress(entry.Address); err != nil {
-		return fmt.Errorf("invalid address: %w", err)
-	}
-
-	if err := v.checkPhone(entry.PhoneNumber); err != nil {
-		return fmt.Errorf("invalid phone number: %w", err)
-	}
-
-	if err := v.checkDepartment(entry.Department); err != nil {
-		return fmt.Errorf("invalid department: %w", err)
-	}
-
-	if err := v.checkPosition(entry.Position); err != nil {
-		return fmt.Errorf("invalid position: %w", err)
-	}
+	return nil
+}
 
-	if err := v.checkSalary(entry.Salary); err != nil {
-		return fmt.Errorf("invalid salary: %w", err)
+func (v *dataValidator) ValidateEntryFields(entry *schemas.TaskImportDTO) []interfaces.FieldError {
+	checks := []struct {
+		field string
+		err   error
+	}{
+		{"Name", v.checkName(entry.Name)},
+		{"Email", v.checkEmail(entry.Email)},
+		{"Age", v.checkAge(entry.Age)},
+		{"Address", v.checkAddress(entry.Address)},
+		{"Phone Number", v.checkPhone(entry.PhoneNumber)},
+		{"Department", v.checkDepartment(entry.Department)},
+		{"Position", v.checkPosition(entry.Position)},
+		{"Salary", v.checkSalary(entry.Salary)},
+	}
+
+	var errs []interfaces.FieldError
+	for _, check := range checks {
+		if check.err != nil {
+			errs = append(errs, interfaces.FieldError{
+				Field:   check.field,
+				Message: check.err.Error(),
+			})
+		}
 	}
 
-	return nil
+	return errs
 }
 
 func (v *dataValidator) ValidateBatch(entries []schemas.TaskImportDTO) error {
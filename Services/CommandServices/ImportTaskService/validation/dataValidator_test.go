@@ -187,3 +187,29 @@ func TestDataValidatorBatch(t *testing.T) {
 		})
 	}
 }
+
+func TestDataValidatorEntryFields(t *testing.T) {
+	logger := logrus.New()
+	validator := NewDataValidator(logger)
+
+	entry := schemas.TaskImportDTO{
+		Name:        "",
+		Email:       "not-an-email",
+		Age:         30,
+		Address:     "123 Main St",
+		PhoneNumber: "123-456-7890",
+		Department:  "Engineering",
+		Position:    "Software Engineer",
+		Salary:      -1,
+		HireDate:    time.Now(),
+	}
+
+	errs := validator.ValidateEntryFields(&entry)
+
+	fields := make([]string, 0, len(errs))
+	for _, err := range errs {
+		fields = append(fields, err.Field)
+		assert.NotEmpty(t, err.Message)
+	}
+	assert.Equal(t, []string{"Name", "Email", "Salary"}, fields)
+}
@@ -7,4 +7,13 @@ import (
 type Validator interface {
 	ValidateEntry(entry *schemas.TaskImportDTO) error
 	ValidateBatch(entries []schemas.TaskImportDTO) error
+	// ValidateEntryFields checks every rule and reports all violations instead of stopping at the first
+	ValidateEntryFields(entry *schemas.TaskImportDTO) []FieldError
+}
+
+// FieldError describes a rule violation on a single field
+type FieldError struct {
+	// Field is the import column the violation belongs to, e.g. "Phone Number"
+	Field   string
+	Message string
 }
@@ -0,0 +1,210 @@
+package TaskCommandService
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	repoInterfaces "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	validatorInterfaces "taskmanager/Services/CommandServices/ImportTaskService/validation/interfaces"
+	"taskmanager/Services/CommandServices/TaskCommandService/interfaces"
+	taskSchemas "taskmanager/Services/CommandServices/TaskCommandService/schemas"
+
+	"github.com/sirupsen/logrus"
+)
+
+type taskCommandService struct {
+	repo      repoInterfaces.TaskCommandRepository
+	validator validatorInterfaces.Validator
+	logger    *logrus.Logger
+	// fieldKeys maps the import column names used by the validator to request fields
+	fieldKeys map[string]string
+}
+
+// NewTaskCommandService creates a new instance of TaskCommandService
+func NewTaskCommandService(
+	repo repoInterfaces.TaskCommandRepository,
+	validator validatorInterfaces.Validator,
+	logger *logrus.Logger,
+) interfaces.TaskCommandService {
+	fieldKeys := make(map[string]string)
+	for _, column := range schemas.StandardMappingProfile().Columns {
+		fieldKeys[column.Header] = column.Key
+	}
+
+	return &taskCommandService{
+		repo:      repo,
+		validator: validator,
+		logger:    logger,
+		fieldKeys: fieldKeys,
+	}
+}
+
+func (s *taskCommandService) CreateTask(ctx context.Context, claims jwt.ClientClaims, request taskSchemas.TaskRequestDTO) (*taskSchemas.TaskDTO, error) {
+	if err := claims.ValidateClient(); err != nil {
+		return nil, fmt.Errorf("invalid client: %w", err)
+	}
+
+	task, err := s.buildTask(request)
+	if err != nil {
+		return nil, err
+	}
+	task.AssignClient(claims.ClientName, claims.ClientID)
+
+	if err := s.repo.CreateTask(ctx, task); err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	return taskSchemas.MapTaskToDTO(task), nil
+}
+
+func (s *taskCommandService) UpdateTask(ctx context.Context, claims jwt.ClientClaims, id int, request taskSchemas.TaskRequestDTO) (*taskSchemas.TaskDTO, error) {
+	if err := claims.ValidateClient(); err != nil {
+		return nil, fmt.Errorf("invalid client: %w", err)
+	}
+
+	task, err := s.buildTask(request)
+	if err != nil {
+		return nil, err
+	}
+	task.ID = id
+	task.AssignClient(claims.ClientName, claims.ClientID)
+
+	if err := s.repo.UpdateTask(ctx, task); err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	return taskSchemas.MapTaskToDTO(task), nil
+}
+
+func (s *taskCommandService) PatchTask(ctx context.Context, claims jwt.ClientClaims, id int, patch taskSchemas.TaskPatchDTO) (*taskSchemas.TaskDTO, error) {
+	if err := claims.ValidateClient(); err != nil {
+		return nil, fmt.Errorf("invalid client: %w", err)
+	}
+
+	task, err := s.repo.PatchTask(ctx, claims.ClientID, id, func(current schemas.TaskModel) (*schemas.TaskModel, error) {
+		task, err := s.buildTask(patch.Apply(current))
+		if err != nil {
+			return nil, err
+		}
+		task.AssignClient(claims.ClientName, claims.ClientID)
+		return task, nil
+	})
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	return taskSchemas.MapTaskToDTO(task), nil
+}
+
+func (s *taskCommandService) DeleteTask(ctx context.Context, claims jwt.ClientClaims, id int) error {
+	if err := claims.ValidateClient(); err != nil {
+		return fmt.Errorf("invalid client: %w", err)
+	}
+
+	if err := s.repo.DeactivateTask(ctx, claims.ClientID, id); err != nil {
+		return translateRepoError(err)
+	}
+
+	return nil
+}
+
+func (s *taskCommandService) ChangeTaskStatus(ctx context.Context, claims jwt.ClientClaims, id int, request taskSchemas.TaskStatusRequestDTO) (*taskSchemas.TaskStatusDTO, error) {
+	if err := claims.ValidateClient(); err != nil {
+		return nil, fmt.Errorf("invalid client: %w", err)
+	}
+
+	status, err := taskSchemas.ParseTaskStatus(request.Status)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", interfaces.ErrInvalidStatus, err)
+	}
+
+	recorded, err := s.repo.GetTaskStatus(ctx, claims.ClientID, id)
+	if err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	current := recorded
+	if current == "" {
+		current = taskSchemas.InitialTaskStatus
+	}
+	if err := taskSchemas.CheckTaskStatusTransition(current, status, request.Reopen); err != nil {
+		return nil, fmt.Errorf("%w: %v", interfaces.ErrIllegalTransition, err)
+	}
+
+	entry := &taskSchemas.TaskStatusModel{
+		TaskID:            id,
+		ClientName:        claims.ClientName,
+		ClientID:          claims.ClientID,
+		Status:            status,
+		StatusDescription: strings.TrimSpace(request.Description),
+		UpdatedBy:         claims.ClientName,
+	}
+	if err := s.repo.AppendTaskStatus(ctx, entry, recorded); err != nil {
+		return nil, translateRepoError(err)
+	}
+
+	s.logger.WithFields(logrus.Fields{
+		"task_id": id,
+		"from":    current,
+		"to":      status,
+	}).Info("Task status changed")
+	return entry.MapToDTO(current), nil
+}
+
+// buildTask validates a request with the import rules and converts it to an active task
+func (s *taskCommandService) buildTask(request taskSchemas.TaskRequestDTO) (*schemas.TaskModel, error) {
+	entry := schemas.TaskImportDTO{
+		Name:        strings.TrimSpace(request.Name),
+		Email:       strings.TrimSpace(request.Email),
+		Age:         request.Age,
+		Address:     strings.TrimSpace(request.Address),
+		PhoneNumber: strings.TrimSpace(request.PhoneNumber),
+		Department:  strings.TrimSpace(request.Department),
+		Position:    strings.TrimSpace(request.Position),
+		Salary:      request.Salary,
+	}
+
+	var fields []taskSchemas.TaskFieldErrorDTO
+	for _, problem := range s.validator.ValidateEntryFields(&entry) {
+		fields = append(fields, taskSchemas.TaskFieldErrorDTO{
+			Field:  s.fieldKeys[problem.Field],
+			Reason: problem.Message,
+		})
+	}
+
+	hireDate, err := time.Parse(taskSchemas.HireDateFormat, strings.TrimSpace(request.HireDate))
+	if err != nil {
+		fields = append(fields, taskSchemas.TaskFieldErrorDTO{
+			Field:  schemas.ColumnHireDate,
+			Reason: "hire date must be formatted YYYY-MM-DD",
+		})
+	}
+	entry.HireDate = hireDate
+
+	if len(fields) > 0 {
+		return nil, &interfaces.TaskValidationError{Fields: fields}
+	}
+
+	var task schemas.TaskModel
+	task.MapFromDTO(entry)
+	return &task, nil
+}
+
+// translateRepoError maps repository sentinels onto the errors of this service
+func translateRepoError(err error) error {
+	switch {
+	case errors.Is(err, repoInterfaces.ErrTaskNotFound):
+		return interfaces.ErrTaskNotFound
+	case errors.Is(err, repoInterfaces.ErrDuplicateTask):
+		return interfaces.ErrDuplicateTask
+	case errors.Is(err, repoInterfaces.ErrTaskStatusChanged):
+		return interfaces.ErrStatusConflict
+	default:
+		return err
+	}
+}
@@ -0,0 +1,345 @@
+package TaskCommandService
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	repoInterfaces "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	"taskmanager/Services/CommandServices/ImportTaskService/validation"
+	"taskmanager/Services/CommandServices/TaskCommandService/interfaces"
+	taskSchemas "taskmanager/Services/CommandServices/TaskCommandService/schemas"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var testClaims = jwt.ClientClaims{ClientName: "Client One Corp", ClientID: "9ebcc92c-e186-41b3-834b-f75ab3f110ae"}
+
+// MockTaskCommandRepository is a mock implementation of TaskCommandRepository
+type MockTaskCommandRepository struct {
+	mock.Mock
+}
+
+func (m *MockTaskCommandRepository) BeginTaskImport(ctx context.Context) (repoInterfaces.TaskBatchWriter, error) {
+	args := m.Called(ctx)
+	return nil, args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) BeginTaskUpsert(ctx context.Context) (repoInterfaces.TaskBatchWriter, error) {
+	args := m.Called(ctx)
+	return nil, args.Error(1)
+}
+
+// CreateTask assigns ID 1 to the stored task
+func (m *MockTaskCommandRepository) CreateTask(ctx context.Context, task *schemas.TaskModel) error {
+	args := m.Called(ctx, task)
+	task.ID = 1
+	return args.Error(0)
+}
+
+func (m *MockTaskCommandRepository) GetTask(ctx context.Context, clientID string, id int) (*schemas.TaskModel, error) {
+	args := m.Called(ctx, clientID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.TaskModel), args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) UpdateTask(ctx context.Context, task *schemas.TaskModel) error {
+	return m.Called(ctx, task).Error(0)
+}
+
+// PatchTask applies patch to the task the mock returns, as the repository does to the
+// locked row
+func (m *MockTaskCommandRepository) PatchTask(
+	ctx context.Context,
+	clientID string,
+	id int,
+	patch func(current schemas.TaskModel) (*schemas.TaskModel, error),
+) (*schemas.TaskModel, error) {
+	args := m.Called(ctx, clientID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	task, err := patch(*args.Get(0).(*schemas.TaskModel))
+	if err != nil {
+		return nil, err
+	}
+	return task, args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) DeactivateTask(ctx context.Context, clientID string, id int) error {
+	return m.Called(ctx, clientID, id).Error(0)
+}
+
+func (m *MockTaskCommandRepository) GetTaskStatus(ctx context.Context, clientID string, taskID int) (string, error) {
+	args := m.Called(ctx, clientID, taskID)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTaskCommandRepository) AppendTaskStatus(ctx context.Context, entry *taskSchemas.TaskStatusModel, expected string) error {
+	return m.Called(ctx, entry, expected).Error(0)
+}
+
+func validRequest() taskSchemas.TaskRequestDTO {
+	return taskSchemas.TaskRequestDTO{
+		Name:        "John Doe",
+		Email:       "john@example.com",
+		Age:         30,
+		Address:     "123 Elm St",
+		PhoneNumber: "555-1234",
+		Department:  "Sales",
+		Position:    "Manager",
+		Salary:      60000,
+		HireDate:    "2006-01-15",
+	}
+}
+
+func newService(repo *MockTaskCommandRepository) interfaces.TaskCommandService {
+	logger := logrus.New()
+	return NewTaskCommandService(repo, validation.NewDataValidator(logger), logger)
+}
+
+func TestCreateTask(t *testing.T) {
+	tests := []struct {
+		name       string
+		request    func() taskSchemas.TaskRequestDTO
+		mockSetup  func(*MockTaskCommandRepository)
+		wantErr    error
+		wantFields []string
+	}{
+		{
+			name:    "Valid task is created for the calling client",
+			request: validRequest,
+			mockSetup: func(m *MockTaskCommandRepository) {
+				m.On("CreateTask", mock.Anything, mock.MatchedBy(func(task *schemas.TaskModel) bool {
+					return task.ClientID == testClaims.ClientID &&
+						task.ClientName == testClaims.ClientName &&
+						task.IsActive &&
+						task.HireDate.Equal(time.Date(2006, 1, 15, 0, 0, 0, 0, time.UTC))
+				})).Return(nil).Once()
+			},
+		},
+		{
+			name: "Every broken rule is reported by field",
+			request: func() taskSchemas.TaskRequestDTO {
+				request := validRequest()
+				request.Age = 0
+				request.PhoneNumber = "12"
+				request.HireDate = "15/01/2006"
+				return request
+			},
+			mockSetup:  func(m *MockTaskCommandRepository) {},
+			wantErr:    interfaces.ErrInvalidTask,
+			wantFields: []string{"age", "phone_number", "hire_date"},
+		},
+		{
+			name:    "Duplicate email is a conflict",
+			request: validRequest,
+			mockSetup: func(m *MockTaskCommandRepository) {
+				m.On("CreateTask", mock.Anything, mock.Anything).Return(repoInterfaces.ErrDuplicateTask).Once()
+			},
+			wantErr: interfaces.ErrDuplicateTask,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+			tt.mockSetup(mockRepo)
+
+			task, err := newService(mockRepo).CreateTask(context.Background(), testClaims, tt.request())
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, task)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, 1, task.ID)
+				assert.Equal(t, "2006-01-15", task.HireDate)
+			}
+
+			if tt.wantFields != nil {
+				var invalid *interfaces.TaskValidationError
+				assert.ErrorAs(t, err, &invalid)
+				var fields []string
+				for _, field := range invalid.Fields {
+					fields = append(fields, field.Field)
+				}
+				assert.Equal(t, tt.wantFields, fields)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestPatchTask(t *testing.T) {
+	current := &schemas.TaskModel{
+		ID:          7,
+		Name:        "John Doe",
+		Email:       "john@example.com",
+		Age:         30,
+		Address:     "123 Elm St",
+		PhoneNumber: "555-1234",
+		Department:  "Sales",
+		Position:    "Manager",
+		Salary:      60000,
+		HireDate:    time.Date(2006, 1, 15, 0, 0, 0, 0, time.UTC),
+		IsActive:    true,
+		ClientName:  testClaims.ClientName,
+		ClientID:    testClaims.ClientID,
+	}
+	position := "Director"
+	salary := 90000.0
+
+	t.Run("Present fields change and the rest are kept", func(t *testing.T) {
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("PatchTask", mock.Anything, testClaims.ClientID, 7).Return(current, nil).Once()
+
+		task, err := newService(mockRepo).PatchTask(context.Background(), testClaims, 7,
+			taskSchemas.TaskPatchDTO{Position: &position, Salary: &salary})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Director", task.Position)
+		assert.Equal(t, salary, task.Salary)
+		assert.Equal(t, current.Email, task.Email)
+		assert.Equal(t, "2006-01-15", task.HireDate)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Patched task breaking a rule is not stored", func(t *testing.T) {
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("PatchTask", mock.Anything, testClaims.ClientID, 7).Return(current, nil).Once()
+		email := "not an email"
+
+		task, err := newService(mockRepo).PatchTask(context.Background(), testClaims, 7,
+			taskSchemas.TaskPatchDTO{Email: &email})
+
+		assert.ErrorIs(t, err, interfaces.ErrInvalidTask)
+		assert.Nil(t, task)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Task of another client is not found", func(t *testing.T) {
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("PatchTask", mock.Anything, testClaims.ClientID, 7).Return(nil, repoInterfaces.ErrTaskNotFound).Once()
+
+		task, err := newService(mockRepo).PatchTask(context.Background(), testClaims, 7,
+			taskSchemas.TaskPatchDTO{Position: &position})
+
+		assert.ErrorIs(t, err, interfaces.ErrTaskNotFound)
+		assert.Nil(t, task)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestDeleteTask(t *testing.T) {
+	mockRepo := new(MockTaskCommandRepository)
+	mockRepo.On("DeactivateTask", mock.Anything, testClaims.ClientID, 7).Return(nil).Once()
+	mockRepo.On("DeactivateTask", mock.Anything, testClaims.ClientID, 8).Return(repoInterfaces.ErrTaskNotFound).Once()
+
+	service := newService(mockRepo)
+	assert.NoError(t, service.DeleteTask(context.Background(), testClaims, 7))
+	assert.ErrorIs(t, service.DeleteTask(context.Background(), testClaims, 8), interfaces.ErrTaskNotFound)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestChangeTaskStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		recorded  string
+		request   taskSchemas.TaskStatusRequestDTO
+		appendErr error
+		wantErr   error
+	}{
+		{
+			name:     "Task without history starts from PENDING",
+			recorded: "",
+			request:  taskSchemas.TaskStatusRequestDTO{Status: "in_progress", Description: "Work started"},
+		},
+		{
+			name:     "In-progress task is completed",
+			recorded: taskSchemas.TaskStatusInProgress,
+			request:  taskSchemas.TaskStatusRequestDTO{Status: taskSchemas.TaskStatusCompleted},
+		},
+		{
+			name:     "Pending task cannot skip to COMPLETED",
+			recorded: taskSchemas.TaskStatusPending,
+			request:  taskSchemas.TaskStatusRequestDTO{Status: taskSchemas.TaskStatusCompleted},
+			wantErr:  interfaces.ErrIllegalTransition,
+		},
+		{
+			name:     "Completed task cannot move without reopen",
+			recorded: taskSchemas.TaskStatusCompleted,
+			request:  taskSchemas.TaskStatusRequestDTO{Status: taskSchemas.TaskStatusInProgress},
+			wantErr:  interfaces.ErrIllegalTransition,
+		},
+		{
+			name:     "Cancelled task is reopened",
+			recorded: taskSchemas.TaskStatusCancelled,
+			request:  taskSchemas.TaskStatusRequestDTO{Status: taskSchemas.TaskStatusPending, Reopen: true},
+		},
+		{
+			name:     "Reopen cannot move a task between terminal statuses",
+			recorded: taskSchemas.TaskStatusCancelled,
+			request:  taskSchemas.TaskStatusRequestDTO{Status: taskSchemas.TaskStatusCompleted, Reopen: true},
+			wantErr:  interfaces.ErrIllegalTransition,
+		},
+		{
+			name:     "Unknown status is rejected",
+			recorded: taskSchemas.TaskStatusPending,
+			request:  taskSchemas.TaskStatusRequestDTO{Status: "DONE"},
+			wantErr:  interfaces.ErrInvalidStatus,
+		},
+		{
+			name:      "Concurrent change is a conflict",
+			recorded:  taskSchemas.TaskStatusPending,
+			request:   taskSchemas.TaskStatusRequestDTO{Status: taskSchemas.TaskStatusInProgress},
+			appendErr: repoInterfaces.ErrTaskStatusChanged,
+			wantErr:   interfaces.ErrStatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskCommandRepository)
+			mockRepo.On("GetTaskStatus", mock.Anything, testClaims.ClientID, 7).Return(tt.recorded, nil).Maybe()
+			mockRepo.On("AppendTaskStatus", mock.Anything, mock.MatchedBy(func(entry *taskSchemas.TaskStatusModel) bool {
+				return entry.TaskID == 7 &&
+					entry.ClientID == testClaims.ClientID &&
+					entry.UpdatedBy == testClaims.ClientName
+			}), tt.recorded).Return(tt.appendErr).Maybe()
+
+			status, err := newService(mockRepo).ChangeTaskStatus(context.Background(), testClaims, 7, tt.request)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, status)
+				if tt.appendErr == nil {
+					mockRepo.AssertNotCalled(t, "AppendTaskStatus", mock.Anything, mock.Anything, mock.Anything)
+				}
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, strings.ToUpper(tt.request.Status), status.Status)
+				mockRepo.AssertCalled(t, "AppendTaskStatus", mock.Anything, mock.Anything, tt.recorded)
+			}
+		})
+	}
+
+	t.Run("Task of another client is not found", func(t *testing.T) {
+		mockRepo := new(MockTaskCommandRepository)
+		mockRepo.On("GetTaskStatus", mock.Anything, testClaims.ClientID, 7).Return("", repoInterfaces.ErrTaskNotFound).Once()
+
+		status, err := newService(mockRepo).ChangeTaskStatus(context.Background(), testClaims, 7,
+			taskSchemas.TaskStatusRequestDTO{Status: taskSchemas.TaskStatusInProgress})
+
+		assert.ErrorIs(t, err, interfaces.ErrTaskNotFound)
+		assert.Nil(t, status)
+		mockRepo.AssertExpectations(t)
+	})
+}
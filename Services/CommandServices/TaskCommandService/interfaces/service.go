@@ -0,0 +1,62 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/TaskCommandService/schemas"
+)
+
+var (
+	// ErrTaskNotFound is returned when an active task does not exist for the calling client
+	ErrTaskNotFound = errors.New("task not found")
+	// ErrInvalidTask is returned when a task breaks the validation rules
+	ErrInvalidTask = errors.New("invalid task")
+	// ErrDuplicateTask is returned when the client already has an active task with the same email
+	ErrDuplicateTask = errors.New("task with the same email already exists")
+	// ErrInvalidStatus is returned when a status change names an unknown status
+	ErrInvalidStatus = errors.New("invalid task status")
+	// ErrIllegalTransition is returned when a task may not move to the requested status
+	ErrIllegalTransition = errors.New("illegal task status transition")
+	// ErrStatusConflict is returned when another change to the task's status won the race
+	ErrStatusConflict = errors.New("task status was changed by another request, retry")
+)
+
+// TaskValidationError lists every rule a task breaks; it matches ErrInvalidTask
+type TaskValidationError struct {
+	Fields []schemas.TaskFieldErrorDTO
+}
+
+func (e *TaskValidationError) Error() string {
+	reasons := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		reasons[i] = fmt.Sprintf("%s: %s", field.Field, field.Reason)
+	}
+	return fmt.Sprintf("%s: %s", ErrInvalidTask, strings.Join(reasons, "; "))
+}
+
+func (e *TaskValidationError) Unwrap() error {
+	return ErrInvalidTask
+}
+
+// TaskCommandService creates, updates and deactivates single tasks of the calling client
+type TaskCommandService interface {
+	// CreateTask stores a new active task
+	CreateTask(ctx context.Context, claims jwt.ClientClaims, request schemas.TaskRequestDTO) (*schemas.TaskDTO, error)
+
+	// UpdateTask replaces every field of an active task
+	UpdateTask(ctx context.Context, claims jwt.ClientClaims, id int, request schemas.TaskRequestDTO) (*schemas.TaskDTO, error)
+
+	// PatchTask changes the fields present in the patch and keeps the rest
+	PatchTask(ctx context.Context, claims jwt.ClientClaims, id int, patch schemas.TaskPatchDTO) (*schemas.TaskDTO, error)
+
+	// DeleteTask soft deletes an active task by marking it inactive
+	DeleteTask(ctx context.Context, claims jwt.ClientClaims, id int) error
+
+	// ChangeTaskStatus appends a status change to an active task's history, recording the
+	// calling client as the updater
+	ChangeTaskStatus(ctx context.Context, claims jwt.ClientClaims, id int, request schemas.TaskStatusRequestDTO) (*schemas.TaskStatusDTO, error)
+}
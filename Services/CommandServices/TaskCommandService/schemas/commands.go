@@ -0,0 +1,132 @@
+package schemas
+
+import (
+	"time"
+
+	importSchemas "taskmanager/Services/CommandServices/ImportTaskService/schemas"
+)
+
+// HireDateFormat is the layout of hire dates in the task command API
+const HireDateFormat = "2006-01-02"
+
+// TaskRequestDTO is the body of a task create or full update
+type TaskRequestDTO struct {
+	Name        string  `json:"name"`
+	Email       string  `json:"email"`
+	Age         int     `json:"age"`
+	Address     string  `json:"address"`
+	PhoneNumber string  `json:"phone_number"`
+	Department  string  `json:"department"`
+	Position    string  `json:"position"`
+	Salary      float64 `json:"salary"`
+	// HireDate is formatted YYYY-MM-DD
+	HireDate string `json:"hire_date"`
+}
+
+// TaskPatchDTO is the body of a partial task update; absent fields are left unchanged
+type TaskPatchDTO struct {
+	Name        *string  `json:"name,omitempty"`
+	Email       *string  `json:"email,omitempty"`
+	Age         *int     `json:"age,omitempty"`
+	Address     *string  `json:"address,omitempty"`
+	PhoneNumber *string  `json:"phone_number,omitempty"`
+	Department  *string  `json:"department,omitempty"`
+	Position    *string  `json:"position,omitempty"`
+	Salary      *float64 `json:"salary,omitempty"`
+	HireDate    *string  `json:"hire_date,omitempty"`
+}
+
+// Apply returns the full update that results from patching a task
+func (p TaskPatchDTO) Apply(task importSchemas.TaskModel) TaskRequestDTO {
+	request := TaskRequestDTO{
+		Name:        task.Name,
+		Email:       task.Email,
+		Age:         task.Age,
+		Address:     task.Address,
+		PhoneNumber: task.PhoneNumber,
+		Department:  task.Department,
+		Position:    task.Position,
+		Salary:      task.Salary,
+		HireDate:    task.HireDate.Format(HireDateFormat),
+	}
+
+	if p.Name != nil {
+		request.Name = *p.Name
+	}
+	if p.Email != nil {
+		request.Email = *p.Email
+	}
+	if p.Age != nil {
+		request.Age = *p.Age
+	}
+	if p.Address != nil {
+		request.Address = *p.Address
+	}
+	if p.PhoneNumber != nil {
+		request.PhoneNumber = *p.PhoneNumber
+	}
+	if p.Department != nil {
+		request.Department = *p.Department
+	}
+	if p.Position != nil {
+		request.Position = *p.Position
+	}
+	if p.Salary != nil {
+		request.Salary = *p.Salary
+	}
+	if p.HireDate != nil {
+		request.HireDate = *p.HireDate
+	}
+
+	return request
+}
+
+// TaskDTO represents a task returned by the task command API
+type TaskDTO struct {
+	ID          int       `json:"id"`
+	Name        string    `json:"name"`
+	Email       string    `json:"email"`
+	Age         int       `json:"age"`
+	Address     string    `json:"address"`
+	PhoneNumber string    `json:"phone_number"`
+	Department  string    `json:"department"`
+	Position    string    `json:"position"`
+	Salary      float64   `json:"salary"`
+	HireDate    string    `json:"hire_date"`
+	IsActive    bool      `json:"is_active"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// TaskFieldErrorDTO describes why a task field was rejected
+type TaskFieldErrorDTO struct {
+	Field  string `json:"field"`
+	Reason string `json:"reason"`
+}
+
+// TaskCommandResponseDTO represents the response for task commands
+type TaskCommandResponseDTO struct {
+	Success bool                `json:"success"`
+	Message string              `json:"message"`
+	Task    *TaskDTO            `json:"task,omitempty"`
+	Errors  []TaskFieldErrorDTO `json:"errors,omitempty"`
+}
+
+// MapTaskToDTO converts a TaskModel to a TaskDTO
+func MapTaskToDTO(m *importSchemas.TaskModel) *TaskDTO {
+	return &TaskDTO{
+		ID:          m.ID,
+		Name:        m.Name,
+		Email:       m.Email,
+		Age:         m.Age,
+		Address:     m.Address,
+		PhoneNumber: m.PhoneNumber,
+		Department:  m.Department,
+		Position:    m.Position,
+		Salary:      m.Salary,
+		HireDate:    m.HireDate.Format(HireDateFormat),
+		IsActive:    m.IsActive,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
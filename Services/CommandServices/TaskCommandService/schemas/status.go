@@ -0,0 +1,119 @@
+package schemas
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Task statuses, matching the chk_valid_status constraint of task_status
+const (
+	TaskStatusPending    = "PENDING"
+	TaskStatusInProgress = "IN_PROGRESS"
+	TaskStatusCompleted  = "COMPLETED"
+	TaskStatusCancelled  = "CANCELLED"
+)
+
+// InitialTaskStatus is the status of a task that has no status history yet
+const InitialTaskStatus = TaskStatusPending
+
+// taskStatusTransitions lists the statuses each status may move to. COMPLETED and
+// CANCELLED are terminal and can only be left by an explicit reopen.
+var taskStatusTransitions = map[string][]string{
+	TaskStatusPending:    {TaskStatusInProgress, TaskStatusCancelled},
+	TaskStatusInProgress: {TaskStatusPending, TaskStatusCompleted, TaskStatusCancelled},
+	TaskStatusCompleted:  {},
+	TaskStatusCancelled:  {},
+}
+
+// reopenTransitions lists where a terminal status may go when the change is a reopen
+var reopenTransitions = []string{TaskStatusPending, TaskStatusInProgress}
+
+// ParseTaskStatus validates a status name, accepting any letter case
+func ParseTaskStatus(value string) (string, error) {
+	status := strings.ToUpper(strings.TrimSpace(value))
+	if _, ok := taskStatusTransitions[status]; !ok {
+		return "", fmt.Errorf("status must be one of %s, %s, %s or %s",
+			TaskStatusPending, TaskStatusInProgress, TaskStatusCompleted, TaskStatusCancelled)
+	}
+	return status, nil
+}
+
+// IsTerminalTaskStatus reports whether a status can only be left by a reopen
+func IsTerminalTaskStatus(status string) bool {
+	return status == TaskStatusCompleted || status == TaskStatusCancelled
+}
+
+// CheckTaskStatusTransition reports why a task may not move between two statuses, or
+// nil if it may. Leaving a terminal status requires reopen.
+func CheckTaskStatusTransition(from, to string, reopen bool) error {
+	if from == to {
+		return fmt.Errorf("task is already %s", to)
+	}
+
+	allowed := taskStatusTransitions[from]
+	if IsTerminalTaskStatus(from) {
+		if !reopen {
+			return fmt.Errorf("task is %s, send reopen=true to move it to %s", from, to)
+		}
+		allowed = reopenTransitions
+	}
+
+	for _, status := range allowed {
+		if status == to {
+			return nil
+		}
+	}
+	return fmt.Errorf("task cannot move from %s to %s", from, to)
+}
+
+// TaskStatusModel represents a row of the task status history
+type TaskStatusModel struct {
+	ID                int       `db:"id"`
+	TaskID            int       `db:"task_id"`
+	ClientName        string    `db:"client_name"`
+	ClientID          string    `db:"client_id"`
+	Status            string    `db:"status"`
+	StatusDescription string    `db:"status_description"`
+	UpdatedBy         string    `db:"updated_by"`
+	CreatedAt         time.Time `db:"created_at"`
+}
+
+// TaskStatusRequestDTO is the body of a task status change
+type TaskStatusRequestDTO struct {
+	Status      string `json:"status"`
+	Description string `json:"description,omitempty"`
+	// Reopen allows leaving COMPLETED or CANCELLED
+	Reopen bool `json:"reopen,omitempty"`
+}
+
+// TaskStatusDTO represents a recorded task status change
+type TaskStatusDTO struct {
+	ID             int       `json:"id"`
+	TaskID         int       `json:"task_id"`
+	PreviousStatus string    `json:"previous_status"`
+	Status         string    `json:"status"`
+	Description    string    `json:"description,omitempty"`
+	UpdatedBy      string    `json:"updated_by"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// TaskStatusResponseDTO represents the response for a task status change
+type TaskStatusResponseDTO struct {
+	Success bool           `json:"success"`
+	Message string         `json:"message"`
+	Status  *TaskStatusDTO `json:"status,omitempty"`
+}
+
+// MapToDTO converts a TaskStatusModel to a TaskStatusDTO
+func (m *TaskStatusModel) MapToDTO(previousStatus string) *TaskStatusDTO {
+	return &TaskStatusDTO{
+		ID:             m.ID,
+		TaskID:         m.TaskID,
+		PreviousStatus: previousStatus,
+		Status:         m.Status,
+		Description:    m.StatusDescription,
+		UpdatedBy:      m.UpdatedBy,
+		CreatedAt:      m.CreatedAt,
+	}
+}
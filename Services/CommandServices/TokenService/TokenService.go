@@ -0,0 +1,190 @@
+package TokenService
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	repoInterfaces "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	"taskmanager/Services/CommandServices/TokenService/interfaces"
+
+	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
+)
+
+// refreshTokenBytes is the amount of randomness in a generated refresh token
+const refreshTokenBytes = 32
+
+type tokenService struct {
+	repo       repoInterfaces.TokenRepository
+	clients    repoInterfaces.ClientRepository
+	jwtManager *jwt.JWTManager
+	// refreshTTL is the lifetime of a refresh token; rotation does not extend the chain
+	// beyond the lifetime of each new token
+	refreshTTL time.Duration
+	logger     *logrus.Logger
+}
+
+// NewTokenService creates a new instance of TokenService
+func NewTokenService(
+	repo repoInterfaces.TokenRepository,
+	clients repoInterfaces.ClientRepository,
+	jwtManager *jwt.JWTManager,
+	refreshTTL time.Duration,
+	logger *logrus.Logger,
+) interfaces.TokenService {
+	return &tokenService{
+		repo:       repo,
+		clients:    clients,
+		jwtManager: jwtManager,
+		refreshTTL: refreshTTL,
+		logger:     logger,
+	}
+}
+
+func (s *tokenService) IssueTokens(ctx context.Context, claims *jwt.ClientClaims) (*schemas.TokenPairDTO, error) {
+	return s.issue(ctx, claims, "")
+}
+
+func (s *tokenService) Refresh(ctx context.Context, refreshToken string) (*schemas.TokenPairDTO, error) {
+	token, err := s.repo.GetRefreshToken(ctx, hashToken(refreshToken))
+	if errors.Is(err, repoInterfaces.ErrRefreshTokenNotFound) {
+		return nil, interfaces.ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	logger := s.logger.WithFields(logrus.Fields{
+		"client_id": token.ClientID,
+		"family_id": token.FamilyID,
+	})
+	if token.RevokedAt != nil || !time.Now().Before(token.ExpiresAt) {
+		logger.Warn("Revoked or expired refresh token presented")
+		return nil, interfaces.ErrInvalidRefreshToken
+	}
+
+	// A used token coming back means two parties hold the chain; revoke it so both have to
+	// authenticate again
+	reused := token.UsedAt != nil
+	if !reused {
+		// Loses against a concurrent exchange of the same token
+		marked, err := s.repo.UseRefreshToken(ctx, token.ID)
+		if err != nil {
+			return nil, err
+		}
+		reused = !marked
+	}
+	if reused {
+		logger.Warn("Refresh token reused, revoking its chain")
+		if err := s.repo.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, interfaces.ErrInvalidRefreshToken
+	}
+
+	client, err := s.clients.GetClient(ctx, token.ClientID)
+	if err != nil && !errors.Is(err, repoInterfaces.ErrClientNotFound) {
+		return nil, err
+	}
+	if client == nil || !client.IsActive {
+		logger.Warn("Refresh requested for a disabled client, revoking its chain")
+		if err := s.repo.RevokeRefreshTokenFamily(ctx, token.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, interfaces.ErrInvalidRefreshToken
+	}
+
+	// Scopes are read again so that changes apply from the next refresh
+	claims := &jwt.ClientClaims{
+		ClientName: client.ClientName,
+		ClientID:   client.ClientID,
+		Scopes:     client.Scopes,
+	}
+	return s.issue(ctx, claims, token.FamilyID)
+}
+
+func (s *tokenService) Revoke(ctx context.Context, token string) error {
+	if claims, err := s.jwtManager.ValidateToken(token); err == nil {
+		return s.repo.RevokeAccessToken(ctx, claims.Id, claims.ClientID, time.Unix(claims.ExpiresAt, 0))
+	}
+
+	refreshToken, err := s.repo.GetRefreshToken(ctx, hashToken(token))
+	if errors.Is(err, repoInterfaces.ErrRefreshTokenNotFound) {
+		// Expired access tokens land here too; neither needs revoking
+		s.logger.Debug("Revocation requested for an unknown token")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.repo.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyID)
+}
+
+func (s *tokenService) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	return s.repo.IsAccessTokenRevoked(ctx, jti)
+}
+
+func (s *tokenService) PurgeExpired(ctx context.Context) (int64, error) {
+	purged, err := s.repo.PurgeExpiredTokens(ctx)
+	if err != nil {
+		return 0, err
+	}
+	if purged > 0 {
+		s.logger.WithField("row_count", purged).Info("Purged expired tokens")
+	}
+	return purged, nil
+}
+
+// issue generates an access token and a refresh token belonging to familyID, or to a new
+// family when familyID is empty
+func (s *tokenService) issue(ctx context.Context, claims *jwt.ClientClaims, familyID string) (*schemas.TokenPairDTO, error) {
+	accessToken, accessClaims, err := s.jwtManager.GenerateToken(claims.ClientName, claims.ClientID, claims.Scopes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	buf := make([]byte, refreshTokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+	refreshToken := base64.RawURLEncoding.EncodeToString(buf)
+
+	model := &schemas.RefreshTokenModel{
+		ID:         uuid.NewString(),
+		FamilyID:   familyID,
+		ClientName: claims.ClientName,
+		ClientID:   claims.ClientID,
+		TokenHash:  hashToken(refreshToken),
+		ExpiresAt:  time.Now().Add(s.refreshTTL),
+		// The access token is recorded so that revoking the family revokes it too
+		AccessTokenID:   accessClaims.Id,
+		AccessExpiresAt: time.Unix(accessClaims.ExpiresAt, 0),
+	}
+	if model.FamilyID == "" {
+		model.FamilyID = model.ID
+	}
+	if err := s.repo.CreateRefreshToken(ctx, model); err != nil {
+		return nil, err
+	}
+
+	return &schemas.TokenPairDTO{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(s.jwtManager.AccessTTL() / time.Second),
+	}, nil
+}
+
+// hashToken returns the hex SHA-256 of a refresh token. Refresh tokens are random, so a
+// fast unsalted hash is enough to keep them out of the database.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
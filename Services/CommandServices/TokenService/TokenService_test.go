@@ -0,0 +1,321 @@
+package TokenService
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	repoInterfaces "taskmanager/Repository/CommandRepository/interfaces"
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+	"taskmanager/Services/CommandServices/TokenService/interfaces"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const (
+	testClientName = "Client One Corp"
+	testClientID   = "9ebcc92c-e186-41b3-834b-f75ab3f110ae"
+	testFamilyID   = "5d0f3f4e-8a7c-4b8e-9a53-0b4c1f6f2a11"
+	testRefreshTTL = 30 * 24 * time.Hour
+)
+
+// MockTokenRepository is a mock implementation of TokenRepository
+type MockTokenRepository struct {
+	mock.Mock
+}
+
+func (m *MockTokenRepository) CreateRefreshToken(ctx context.Context, token *schemas.RefreshTokenModel) error {
+	return m.Called(ctx, token).Error(0)
+}
+
+func (m *MockTokenRepository) GetRefreshToken(ctx context.Context, tokenHash string) (*schemas.RefreshTokenModel, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.RefreshTokenModel), args.Error(1)
+}
+
+func (m *MockTokenRepository) UseRefreshToken(ctx context.Context, id string) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	return m.Called(ctx, familyID).Error(0)
+}
+
+func (m *MockTokenRepository) RevokeAccessToken(ctx context.Context, jti string, clientID string, expiresAt time.Time) error {
+	return m.Called(ctx, jti, clientID, expiresAt).Error(0)
+}
+
+func (m *MockTokenRepository) IsAccessTokenRevoked(ctx context.Context, jti string) (bool, error) {
+	args := m.Called(ctx, jti)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTokenRepository) PurgeExpiredTokens(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// MockClientRepository is a mock implementation of ClientRepository
+type MockClientRepository struct {
+	mock.Mock
+}
+
+func (m *MockClientRepository) CreateClient(ctx context.Context, client *schemas.ClientModel) error {
+	return m.Called(ctx, client).Error(0)
+}
+
+func (m *MockClientRepository) GetClient(ctx context.Context, clientID string) (*schemas.ClientModel, error) {
+	args := m.Called(ctx, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ClientModel), args.Error(1)
+}
+
+func (m *MockClientRepository) UpdateClientSecret(ctx context.Context, clientID string, secretHash string) (*schemas.ClientModel, error) {
+	args := m.Called(ctx, clientID, secretHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ClientModel), args.Error(1)
+}
+
+func (m *MockClientRepository) UpdateClientScopes(ctx context.Context, clientID string, scopes []string) (*schemas.ClientModel, error) {
+	args := m.Called(ctx, clientID, scopes)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ClientModel), args.Error(1)
+}
+
+func (m *MockClientRepository) DisableClient(ctx context.Context, clientID string) (*schemas.ClientModel, error) {
+	args := m.Called(ctx, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*schemas.ClientModel), args.Error(1)
+}
+
+// newTestManager creates a JWT manager signing with a new P-256 key
+func newTestManager(t *testing.T) *jwt.JWTManager {
+	private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate key: %v", err)
+	}
+	der, err := x509.MarshalECPrivateKey(private)
+	if err != nil {
+		t.Fatalf("Failed to encode key: %v", err)
+	}
+	key, err := jwt.ParseKey("test", pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+	if err != nil {
+		t.Fatalf("Failed to parse key: %v", err)
+	}
+	keys, err := jwt.NewKeySet("test", key)
+	if err != nil {
+		t.Fatalf("Failed to create key set: %v", err)
+	}
+	return jwt.NewJWTManager(keys, 15*time.Minute, nil)
+}
+
+// newTestService creates a token service with fresh mocks
+func newTestService(t *testing.T) (*tokenService, *MockTokenRepository, *MockClientRepository) {
+	repo := new(MockTokenRepository)
+	clients := new(MockClientRepository)
+	manager := newTestManager(t)
+	service := NewTokenService(repo, clients, manager, testRefreshTTL, logrus.New()).(*tokenService)
+	return service, repo, clients
+}
+
+// storedToken returns an unused, unrevoked refresh token of the test family
+func storedToken(value string) *schemas.RefreshTokenModel {
+	return &schemas.RefreshTokenModel{
+		ID:         "0b7e6a7e-3f4b-4f3e-9a43-7c1e5d9b2c01",
+		FamilyID:   testFamilyID,
+		ClientName: testClientName,
+		ClientID:   testClientID,
+		TokenHash:  hashToken(value),
+		ExpiresAt:  time.Now().Add(time.Hour),
+	}
+}
+
+func TestIssueTokens(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := newTestService(t)
+
+	var stored *schemas.RefreshTokenModel
+	repo.On("CreateRefreshToken", ctx, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(1).(*schemas.RefreshTokenModel)
+	}).Return(nil).Once()
+
+	pair, err := service.IssueTokens(ctx, &jwt.ClientClaims{
+		ClientName: testClientName,
+		ClientID:   testClientID,
+		Scopes:     []string{jwt.ScopeTasksRead},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(15*60), pair.ExpiresIn)
+
+	claims, err := service.jwtManager.ValidateToken(pair.AccessToken)
+	assert.NoError(t, err)
+	assert.Equal(t, testClientID, claims.ClientID)
+	assert.Equal(t, []string{jwt.ScopeTasksRead}, claims.Scopes)
+
+	// A new chain starts with the token itself and only the hash is stored
+	assert.Equal(t, stored.ID, stored.FamilyID)
+	assert.Equal(t, hashToken(pair.RefreshToken), stored.TokenHash)
+	assert.NotContains(t, stored.TokenHash, pair.RefreshToken)
+	assert.WithinDuration(t, time.Now().Add(testRefreshTTL), stored.ExpiresAt, time.Minute)
+
+	// The access token is stored with the refresh token so that the family can revoke it
+	assert.Equal(t, claims.Id, stored.AccessTokenID)
+	assert.Equal(t, time.Unix(claims.ExpiresAt, 0), stored.AccessExpiresAt)
+}
+
+func TestRefresh(t *testing.T) {
+	ctx := context.Background()
+	activeClient := &schemas.ClientModel{
+		ClientID:   testClientID,
+		ClientName: testClientName,
+		IsActive:   true,
+		Scopes:     []string{jwt.ScopeTasksRead, jwt.ScopeImportRun},
+	}
+
+	t.Run("Token is exchanged for a pair in the same chain with the current scopes", func(t *testing.T) {
+		service, repo, clients := newTestService(t)
+		token := storedToken("refresh")
+		repo.On("GetRefreshToken", ctx, hashToken("refresh")).Return(token, nil).Once()
+		repo.On("UseRefreshToken", ctx, token.ID).Return(true, nil).Once()
+		clients.On("GetClient", ctx, testClientID).Return(activeClient, nil).Once()
+
+		var stored *schemas.RefreshTokenModel
+		repo.On("CreateRefreshToken", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			stored = args.Get(1).(*schemas.RefreshTokenModel)
+		}).Return(nil).Once()
+
+		pair, err := service.Refresh(ctx, "refresh")
+		assert.NoError(t, err)
+		assert.NotEqual(t, "refresh", pair.RefreshToken)
+		assert.Equal(t, testFamilyID, stored.FamilyID)
+		claims, err := service.jwtManager.ValidateToken(pair.AccessToken)
+		assert.NoError(t, err)
+		assert.Equal(t, activeClient.Scopes, claims.Scopes)
+		assert.Equal(t, claims.Id, stored.AccessTokenID)
+		repo.AssertNotCalled(t, "RevokeRefreshTokenFamily", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unknown token", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		repo.On("GetRefreshToken", ctx, hashToken("refresh")).Return(nil, repoInterfaces.ErrRefreshTokenNotFound).Once()
+
+		_, err := service.Refresh(ctx, "refresh")
+		assert.ErrorIs(t, err, interfaces.ErrInvalidRefreshToken)
+	})
+
+	t.Run("Expired token", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		token := storedToken("refresh")
+		token.ExpiresAt = time.Now().Add(-time.Minute)
+		repo.On("GetRefreshToken", ctx, hashToken("refresh")).Return(token, nil).Once()
+
+		_, err := service.Refresh(ctx, "refresh")
+		assert.ErrorIs(t, err, interfaces.ErrInvalidRefreshToken)
+		repo.AssertNotCalled(t, "UseRefreshToken", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Reused token revokes its chain", func(t *testing.T) {
+		service, repo, clients := newTestService(t)
+		token := storedToken("refresh")
+		usedAt := time.Now().Add(-time.Minute)
+		token.UsedAt = &usedAt
+		repo.On("GetRefreshToken", ctx, hashToken("refresh")).Return(token, nil).Once()
+		repo.On("RevokeRefreshTokenFamily", ctx, testFamilyID).Return(nil).Once()
+
+		_, err := service.Refresh(ctx, "refresh")
+		assert.ErrorIs(t, err, interfaces.ErrInvalidRefreshToken)
+		repo.AssertExpectations(t)
+		clients.AssertNotCalled(t, "GetClient", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Concurrent exchange revokes its chain", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		token := storedToken("refresh")
+		repo.On("GetRefreshToken", ctx, hashToken("refresh")).Return(token, nil).Once()
+		repo.On("UseRefreshToken", ctx, token.ID).Return(false, nil).Once()
+		repo.On("RevokeRefreshTokenFamily", ctx, testFamilyID).Return(nil).Once()
+
+		_, err := service.Refresh(ctx, "refresh")
+		assert.ErrorIs(t, err, interfaces.ErrInvalidRefreshToken)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Disabled client revokes its chain", func(t *testing.T) {
+		service, repo, clients := newTestService(t)
+		token := storedToken("refresh")
+		repo.On("GetRefreshToken", ctx, hashToken("refresh")).Return(token, nil).Once()
+		repo.On("UseRefreshToken", ctx, token.ID).Return(true, nil).Once()
+		repo.On("RevokeRefreshTokenFamily", ctx, testFamilyID).Return(nil).Once()
+		clients.On("GetClient", ctx, testClientID).
+			Return(&schemas.ClientModel{ClientID: testClientID, ClientName: testClientName}, nil).Once()
+
+		_, err := service.Refresh(ctx, "refresh")
+		assert.ErrorIs(t, err, interfaces.ErrInvalidRefreshToken)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "CreateRefreshToken", mock.Anything, mock.Anything)
+	})
+}
+
+func TestRevoke(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Access token is added to the revocation list", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		accessToken, _, err := service.jwtManager.GenerateToken(testClientName, testClientID, nil)
+		assert.NoError(t, err)
+		claims, err := service.jwtManager.ValidateToken(accessToken)
+		assert.NoError(t, err)
+		repo.On("RevokeAccessToken", ctx, claims.Id, testClientID, time.Unix(claims.ExpiresAt, 0)).Return(nil).Once()
+
+		assert.NoError(t, service.Revoke(ctx, accessToken))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Refresh token revokes its chain", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		repo.On("GetRefreshToken", ctx, hashToken("refresh")).Return(storedToken("refresh"), nil).Once()
+		repo.On("RevokeRefreshTokenFamily", ctx, testFamilyID).Return(nil).Once()
+
+		assert.NoError(t, service.Revoke(ctx, "refresh"))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown token is ignored", func(t *testing.T) {
+		service, repo, _ := newTestService(t)
+		repo.On("GetRefreshToken", ctx, hashToken("refresh")).Return(nil, repoInterfaces.ErrRefreshTokenNotFound).Once()
+
+		assert.NoError(t, service.Revoke(ctx, "refresh"))
+		repo.AssertNotCalled(t, "RevokeRefreshTokenFamily", mock.Anything, mock.Anything)
+	})
+}
+
+func TestPurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	service, repo, _ := newTestService(t)
+	repo.On("PurgeExpiredTokens", ctx).Return(int64(3), nil).Once()
+
+	purged, err := service.PurgeExpired(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+	repo.AssertExpectations(t)
+}
@@ -0,0 +1,34 @@
+package interfaces
+
+import (
+	"context"
+	"errors"
+
+	"taskmanager/RequestControllers/httpSetup/jwt"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
+)
+
+// ErrInvalidRefreshToken is returned when a refresh token is unknown, expired, revoked or
+// already used, or its client has been disabled
+var ErrInvalidRefreshToken = errors.New("invalid refresh token")
+
+// TokenService issues access and refresh token pairs and revokes them
+type TokenService interface {
+	// IssueTokens issues an access token and a refresh token starting a new rotation chain
+	IssueTokens(ctx context.Context, claims *jwt.ClientClaims) (*schemas.TokenPairDTO, error)
+
+	// Refresh exchanges a refresh token for a new pair. A refresh token can be exchanged
+	// once; presenting it again revokes every token of its chain.
+	Refresh(ctx context.Context, refreshToken string) (*schemas.TokenPairDTO, error)
+
+	// Revoke revokes an access token until it expires, or a refresh token with its chain
+	// and the access tokens issued from it. Unknown tokens are ignored.
+	Revoke(ctx context.Context, token string) error
+
+	// IsRevoked reports whether the access token with the given ID has been revoked
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+
+	// PurgeExpired deletes stored refresh tokens and revocations whose tokens have expired,
+	// returning how many were deleted
+	PurgeExpired(ctx context.Context) (int64, error)
+}
@@ -2,10 +2,14 @@ package TaskQueryService
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"math"
+	"strings"
 	repoInterfaces "taskmanager/Repository/QueryRepository/interfaces"
 	serviceInterfaces "taskmanager/Services/QueryServices/TaskQueryService/interfaces"
 	"taskmanager/Services/QueryServices/TaskQueryService/validation"
+	"time"
 
 	"github.com/sirupsen/logrus"
 )
@@ -14,17 +18,21 @@ type taskQueryService struct {
 	repo      repoInterfaces.TaskQueryRepository
 	validator *validation.QueryValidator
 	logger    *logrus.Logger
+	// stuckAfter is how long a task may stay IN_PROGRESS before analytics report it as stuck
+	stuckAfter time.Duration
 }
 
 // NewTaskQueryService creates a new instance of TaskQueryService
 func NewTaskQueryService(
 	repo repoInterfaces.TaskQueryRepository,
 	logger *logrus.Logger,
+	stuckAfter time.Duration,
 ) serviceInterfaces.TaskQueryService {
 	return &taskQueryService{
-		repo:      repo,
-		validator: validation.NewQueryValidator(),
-		logger:    logger,
+		repo:       repo,
+		validator:  validation.NewQueryValidator(),
+		logger:     logger,
+		stuckAfter: stuckAfter,
 	}
 }
 
@@ -32,10 +40,14 @@ func (s *taskQueryService) GetActiveTasks(
 	ctx context.Context,
 	clientName string,
 	clientID string,
+	filter serviceInterfaces.TaskFilter,
+	sort serviceInterfaces.TaskSort,
+	page serviceInterfaces.PageRequest,
 ) (*serviceInterfaces.TasksResponseDTO, error) {
 	s.logger.WithFields(logrus.Fields{
 		"client_name": clientName,
 		"client_id":   clientID,
+		"status":      filter.Status,
 	}).Info("Processing GetActiveTasks request")
 	// Validate input parameters
 	if err := s.validator.ValidateClientParams(clientName, clientID); err != nil {
@@ -46,8 +58,19 @@ func (s *taskQueryService) GetActiveTasks(
 		}, nil
 	}
 
-	// Get tasks from repository
-	tasks, err := s.repo.GetActiveTasks(ctx, clientName, clientID)
+	repoFilter, repoPage, err := s.taskQuery(filter, sort, page)
+	if err != nil {
+		s.logger.WithError(err).Error("Validation failed for GetActiveTasks")
+		return &serviceInterfaces.TasksResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	// Get tasks from repository, one more than the page to learn whether another follows
+	limit := repoPage.Limit
+	repoPage.Limit++
+	tasks, err := s.repo.GetActiveTasks(ctx, clientName, clientID, repoFilter, repoPage)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to get active tasks")
 		return &serviceInterfaces.TasksResponseDTO{
@@ -58,18 +81,33 @@ func (s *taskQueryService) GetActiveTasks(
 
 	s.logger.WithField("task_count", len(tasks)).Info("Retrieved tasks from repository")
 
+	var nextCursor string
+	if len(tasks) > limit {
+		tasks = tasks[:limit]
+		last := tasks[limit-1]
+		nextCursor = encodeCursor(taskCursor{
+			Sort:       repoPage.SortBy,
+			Descending: repoPage.Descending,
+			Value:      repoInterfaces.FormatSortValue(last, repoPage.SortBy),
+			ID:         last.ID,
+		})
+	}
+
 	// Map repository data to DTOs
 	var taskDTOs []serviceInterfaces.TaskDetailDTO
 	for _, task := range tasks {
 		taskDTOs = append(taskDTOs, serviceInterfaces.TaskDetailDTO{
-			ID:         task.ID,
-			Name:       task.Name,
-			Email:      task.Email,
-			Department: task.Department,
-			Position:   task.Position,
-			IsActive:   task.IsActive,
-			ClientName: task.ClientName,
-			ClientID:   task.ClientID,
+			ID:                task.ID,
+			Name:              task.Name,
+			Email:             task.Email,
+			Department:        task.Department,
+			Position:          task.Position,
+			IsActive:          task.IsActive,
+			ClientName:        task.ClientName,
+			ClientID:          task.ClientID,
+			Status:            task.CurrentStatus,
+			StatusDescription: task.StatusDescription,
+			StatusUpdatedAt:   task.StatusUpdatedAt,
 		})
 	}
 
@@ -78,6 +116,7 @@ func (s *taskQueryService) GetActiveTasks(
 		Message:    "Successfully retrieved active tasks",
 		Tasks:      taskDTOs,
 		TotalCount: len(taskDTOs),
+		NextCursor: nextCursor,
 	}
 
 	s.logger.WithField("response", response).Debug("Sending response")
@@ -88,6 +127,7 @@ func (s *taskQueryService) GetTaskStatusHistory(
 	ctx context.Context,
 	clientName string,
 	clientID string,
+	page serviceInterfaces.PageRequest,
 ) (*serviceInterfaces.StatusHistoryResponseDTO, error) {
 	s.logger.WithFields(logrus.Fields{
 		"client_name": clientName,
@@ -101,8 +141,18 @@ func (s *taskQueryService) GetTaskStatusHistory(
 		}, nil
 	}
 
-	// Get status history from repository
-	history, err := s.repo.GetTaskStatusHistory(ctx, clientName, clientID)
+	repoPage, err := s.historyPage(page)
+	if err != nil {
+		return &serviceInterfaces.StatusHistoryResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	// Get status history from repository, one more than the page to learn whether another follows
+	limit := repoPage.Limit
+	repoPage.Limit++
+	history, err := s.repo.GetTaskStatusHistory(ctx, clientName, clientID, repoPage)
 	if err != nil {
 		s.logger.WithError(err).Error("Failed to get task status history")
 		return &serviceInterfaces.StatusHistoryResponseDTO{
@@ -113,6 +163,13 @@ func (s *taskQueryService) GetTaskStatusHistory(
 
 	s.logger.WithField("task_count", len(history)).Info("Retrieved task history from repository")
 
+	var nextCursor string
+	if len(history) > limit {
+		history = history[:limit]
+		last := history[limit-1]
+		nextCursor = encodeCursor(historyCursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
 	// Map repository data to DTOs
 	var historyDTOs []serviceInterfaces.StatusDetailDTO
 	for _, status := range history {
@@ -130,8 +187,470 @@ func (s *taskQueryService) GetTaskStatusHistory(
 		Message:    "Successfully retrieved status history",
 		History:    historyDTOs,
 		TotalCount: len(historyDTOs),
+		NextCursor: nextCursor,
+	}
+
+	s.logger.WithField("response", response).Debug("Sending response")
+	return response, nil
+}
+
+func (s *taskQueryService) GetTask(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	id int,
+) (*serviceInterfaces.TaskResponseDTO, error) {
+	s.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"task_id":     id,
+	}).Info("Processing GetTask request")
+	// Validate input parameters
+	if err := s.validator.ValidateClientParams(clientName, clientID); err != nil {
+		return &serviceInterfaces.TaskResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	task, err := s.repo.GetTask(ctx, clientName, clientID, id)
+	if errors.Is(err, repoInterfaces.ErrTaskNotFound) {
+		return &serviceInterfaces.TaskResponseDTO{
+			Success: false,
+			Message: serviceInterfaces.ErrTaskNotFound.Error(),
+		}, serviceInterfaces.ErrTaskNotFound
+	}
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get task")
+		return &serviceInterfaces.TaskResponseDTO{
+			Success: false,
+			Message: "Failed to retrieve task",
+		}, err
+	}
+
+	timeline, err := s.repo.GetTaskTimeline(ctx, clientID, id)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get task timeline")
+		return &serviceInterfaces.TaskResponseDTO{
+			Success: false,
+			Message: "Failed to retrieve task",
+		}, err
+	}
+
+	timelineDTOs := make([]serviceInterfaces.StatusDetailDTO, 0, len(timeline))
+	for _, status := range timeline {
+		timelineDTOs = append(timelineDTOs, serviceInterfaces.StatusDetailDTO{
+			TaskID:            status.TaskID,
+			Status:            status.Status,
+			StatusDescription: status.StatusDescription,
+			UpdatedBy:         status.UpdatedBy,
+			CreatedAt:         status.CreatedAt,
+		})
+	}
+
+	return &serviceInterfaces.TaskResponseDTO{
+		Success: true,
+		Message: "Successfully retrieved task",
+		Task: &serviceInterfaces.TaskDTO{
+			ID:                task.ID,
+			Name:              task.Name,
+			Email:             task.Email,
+			Age:               task.Age,
+			Address:           task.Address,
+			PhoneNumber:       task.PhoneNumber,
+			Department:        task.Department,
+			Position:          task.Position,
+			Salary:            task.Salary,
+			HireDate:          task.HireDate.Format("2006-01-02"),
+			IsActive:          task.IsActive,
+			ClientName:        task.ClientName,
+			ClientID:          task.ClientID,
+			CreatedAt:         task.CreatedAt,
+			UpdatedAt:         task.UpdatedAt,
+			Status:            task.CurrentStatus,
+			StatusDescription: task.StatusDescription,
+			StatusUpdatedAt:   task.StatusUpdatedAt,
+			Timeline:          timelineDTOs,
+		},
+	}, nil
+}
+
+func (s *taskQueryService) SearchTasks(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	text string,
+	page serviceInterfaces.PageRequest,
+) (*serviceInterfaces.TaskSearchResponseDTO, error) {
+	s.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"text":        text,
+	}).Info("Processing SearchTasks request")
+	// Validate input parameters
+	if err := s.validator.ValidateClientParams(clientName, clientID); err != nil {
+		return &serviceInterfaces.TaskSearchResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	text, repoPage, err := s.searchQuery(text, page)
+	if err != nil {
+		return &serviceInterfaces.TaskSearchResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	// Search the repository, one more than the page to learn whether another follows
+	limit := repoPage.Limit
+	repoPage.Limit++
+	results, err := s.repo.SearchTasks(ctx, clientName, clientID, text, repoPage)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to search tasks")
+		return &serviceInterfaces.TaskSearchResponseDTO{
+			Success: false,
+			Message: "Failed to search tasks",
+		}, err
+	}
+
+	var nextCursor string
+	if len(results) > limit {
+		results = results[:limit]
+		last := results[limit-1]
+		nextCursor = encodeCursor(searchCursor{Text: text, Rank: last.Rank, ID: last.ID})
+	}
+
+	// Map repository data to DTOs
+	var resultDTOs []serviceInterfaces.TaskSearchResultDTO
+	for _, result := range results {
+		resultDTOs = append(resultDTOs, serviceInterfaces.TaskSearchResultDTO{
+			ID:         result.ID,
+			Name:       result.Name,
+			Email:      result.Email,
+			Address:    result.Address,
+			Department: result.Department,
+			Position:   result.Position,
+			Status:     result.CurrentStatus,
+			Rank:       result.Rank,
+		})
+	}
+
+	response := &serviceInterfaces.TaskSearchResponseDTO{
+		Success:    true,
+		Message:    "Successfully searched tasks",
+		Results:    resultDTOs,
+		TotalCount: len(resultDTOs),
+		NextCursor: nextCursor,
 	}
 
 	s.logger.WithField("response", response).Debug("Sending response")
 	return response, nil
 }
+
+func (s *taskQueryService) GetGroupReport(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	groupBy string,
+) (*serviceInterfaces.GroupReportResponseDTO, error) {
+	s.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"group_by":    groupBy,
+	}).Info("Processing GetGroupReport request")
+	// Validate input parameters
+	if err := s.validator.ValidateClientParams(clientName, clientID); err != nil {
+		return &serviceInterfaces.GroupReportResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	groupBy, err := s.validator.ValidateGroupBy(groupBy)
+	if err != nil {
+		return &serviceInterfaces.GroupReportResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	report, err := s.repo.GetGroupReport(ctx, clientName, clientID, groupBy)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get group report")
+		return &serviceInterfaces.GroupReportResponseDTO{
+			Success: false,
+			Message: "Failed to retrieve report",
+		}, err
+	}
+
+	// Map repository data to DTOs
+	var groupDTOs []serviceInterfaces.GroupReportDTO
+	for _, group := range report {
+		groupDTOs = append(groupDTOs, serviceInterfaces.GroupReportDTO{
+			Group:         group.Group,
+			Headcount:     group.Headcount,
+			AverageSalary: group.AverageSalary,
+			MinSalary:     group.MinSalary,
+			MaxSalary:     group.MaxSalary,
+			TotalSalary:   group.TotalSalary,
+			AverageAge:    group.AverageAge,
+		})
+	}
+
+	return &serviceInterfaces.GroupReportResponseDTO{
+		Success:    true,
+		Message:    "Successfully retrieved report",
+		GroupBy:    groupBy,
+		Groups:     groupDTOs,
+		TotalCount: len(groupDTOs),
+	}, nil
+}
+
+func (s *taskQueryService) GetHireReport(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	period string,
+) (*serviceInterfaces.HireReportResponseDTO, error) {
+	s.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"period":      period,
+	}).Info("Processing GetHireReport request")
+	// Validate input parameters
+	if err := s.validator.ValidateClientParams(clientName, clientID); err != nil {
+		return &serviceInterfaces.HireReportResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	period, err := s.validator.ValidatePeriod(period)
+	if err != nil {
+		return &serviceInterfaces.HireReportResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	report, err := s.repo.GetHireReport(ctx, clientName, clientID, period)
+	if err != nil {
+		s.logger.WithError(err).Error("Failed to get hire report")
+		return &serviceInterfaces.HireReportResponseDTO{
+			Success: false,
+			Message: "Failed to retrieve report",
+		}, err
+	}
+
+	layout := "2006-01"
+	if period == repoInterfaces.PeriodYear {
+		layout = "2006"
+	}
+
+	// Map repository data to DTOs
+	var hireDTOs []serviceInterfaces.HireCountDTO
+	for _, count := range report {
+		hireDTOs = append(hireDTOs, serviceInterfaces.HireCountDTO{
+			Period: count.Period.Format(layout),
+			Hires:  count.Hires,
+		})
+	}
+
+	return &serviceInterfaces.HireReportResponseDTO{
+		Success:    true,
+		Message:    "Successfully retrieved report",
+		Period:     period,
+		Hires:      hireDTOs,
+		TotalCount: len(hireDTOs),
+	}, nil
+}
+
+func (s *taskQueryService) GetStatusAnalytics(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	weeks string,
+) (*serviceInterfaces.StatusAnalyticsResponseDTO, error) {
+	s.logger.WithFields(logrus.Fields{
+		"client_name": clientName,
+		"client_id":   clientID,
+		"weeks":       weeks,
+	}).Info("Processing GetStatusAnalytics request")
+	// Validate input parameters
+	if err := s.validator.ValidateClientParams(clientName, clientID); err != nil {
+		return &serviceInterfaces.StatusAnalyticsResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	weekCount, err := s.validator.ValidateWeeks(weeks)
+	if err != nil {
+		return &serviceInterfaces.StatusAnalyticsResponseDTO{
+			Success: false,
+			Message: fmt.Sprintf("Invalid parameters: %v", err),
+		}, nil
+	}
+
+	failed := func(err error, message string) (*serviceInterfaces.StatusAnalyticsResponseDTO, error) {
+		s.logger.WithError(err).Error(message)
+		return &serviceInterfaces.StatusAnalyticsResponseDTO{
+			Success: false,
+			Message: "Failed to retrieve status analytics",
+		}, err
+	}
+
+	durations, err := s.repo.GetStatusDurations(ctx, clientName, clientID)
+	if err != nil {
+		return failed(err, "Failed to get status durations")
+	}
+	throughput, err := s.repo.GetCompletionThroughput(ctx, clientName, clientID, weekCount)
+	if err != nil {
+		return failed(err, "Failed to get completion throughput")
+	}
+	stuck, err := s.repo.CountStuckTasks(ctx, clientName, clientID, s.stuckAfter)
+	if err != nil {
+		return failed(err, "Failed to count stuck tasks")
+	}
+
+	// Map repository data to DTOs
+	var durationDTOs []serviceInterfaces.StatusDurationDTO
+	for _, duration := range durations {
+		durationDTOs = append(durationDTOs, serviceInterfaces.StatusDurationDTO{
+			Status:         duration.Status,
+			Count:          duration.Count,
+			AverageSeconds: duration.AverageSeconds,
+			MedianSeconds:  duration.MedianSeconds,
+			P90Seconds:     duration.P90Seconds,
+			P95Seconds:     duration.P95Seconds,
+		})
+	}
+
+	var throughputDTOs []serviceInterfaces.WeeklyThroughputDTO
+	for _, week := range throughput {
+		throughputDTOs = append(throughputDTOs, serviceInterfaces.WeeklyThroughputDTO{
+			WeekStart: week.WeekStart.Format("2006-01-02"),
+			Completed: week.Completed,
+		})
+	}
+
+	return &serviceInterfaces.StatusAnalyticsResponseDTO{
+		Success:    true,
+		Message:    "Successfully retrieved status analytics",
+		Durations:  durationDTOs,
+		Throughput: throughputDTOs,
+		Stuck: &serviceInterfaces.StuckTasksDTO{
+			ThresholdHours: s.stuckAfter.Hours(),
+			Count:          stuck,
+		},
+	}, nil
+}
+
+// taskQuery validates the raw filter, sort and page of an active tasks request
+func (s *taskQueryService) taskQuery(
+	filter serviceInterfaces.TaskFilter,
+	sort serviceInterfaces.TaskSort,
+	page serviceInterfaces.PageRequest,
+) (repoInterfaces.TaskFilter, repoInterfaces.TaskPage, error) {
+	var repoFilter repoInterfaces.TaskFilter
+	var repoPage repoInterfaces.TaskPage
+	var err error
+
+	if repoFilter.Status, err = s.validator.ValidateStatus(filter.Status); err != nil {
+		return repoFilter, repoPage, err
+	}
+	repoFilter.Department = strings.TrimSpace(filter.Department)
+	repoFilter.Position = strings.TrimSpace(filter.Position)
+	if repoFilter.HiredFrom, err = s.validator.ValidateDate("hired_from", filter.HiredFrom); err != nil {
+		return repoFilter, repoPage, err
+	}
+	if repoFilter.HiredTo, err = s.validator.ValidateDate("hired_to", filter.HiredTo); err != nil {
+		return repoFilter, repoPage, err
+	}
+	if repoFilter.MinSalary, err = s.validator.ValidateAmount("min_salary", filter.MinSalary); err != nil {
+		return repoFilter, repoPage, err
+	}
+	if repoFilter.MaxSalary, err = s.validator.ValidateAmount("max_salary", filter.MaxSalary); err != nil {
+		return repoFilter, repoPage, err
+	}
+
+	if repoPage.Limit, err = s.validator.ValidateLimit(page.Limit); err != nil {
+		return repoFilter, repoPage, err
+	}
+	if repoPage.SortBy, repoPage.Descending, err = s.validator.ValidateSort(sort.Field, sort.Order); err != nil {
+		return repoFilter, repoPage, err
+	}
+	if repoPage.SortBy == "" {
+		repoPage.SortBy = repoInterfaces.SortByID
+	}
+
+	if page.Cursor != "" {
+		var cursor taskCursor
+		if err := decodeCursor(page.Cursor, &cursor); err != nil {
+			return repoFilter, repoPage, err
+		}
+		if cursor.Sort != repoPage.SortBy || cursor.Descending != repoPage.Descending {
+			return repoFilter, repoPage, fmt.Errorf("invalid cursor: it was issued for a different sort")
+		}
+		if !validCursorID(cursor.ID) || !repoInterfaces.ValidSortValue(cursor.Sort, cursor.Value) {
+			return repoFilter, repoPage, fmt.Errorf("invalid cursor")
+		}
+		repoPage.After = &repoInterfaces.TaskCursor{SortValue: cursor.Value, ID: cursor.ID}
+	}
+
+	return repoFilter, repoPage, nil
+}
+
+// historyPage validates the raw page of a status history request
+func (s *taskQueryService) historyPage(page serviceInterfaces.PageRequest) (repoInterfaces.HistoryPage, error) {
+	limit, err := s.validator.ValidateLimit(page.Limit)
+	if err != nil {
+		return repoInterfaces.HistoryPage{}, err
+	}
+
+	repoPage := repoInterfaces.HistoryPage{Limit: limit}
+	if page.Cursor != "" {
+		var cursor historyCursor
+		if err := decodeCursor(page.Cursor, &cursor); err != nil {
+			return repoInterfaces.HistoryPage{}, err
+		}
+		if !validCursorID(cursor.ID) {
+			return repoInterfaces.HistoryPage{}, fmt.Errorf("invalid cursor")
+		}
+		repoPage.After = &repoInterfaces.HistoryCursor{CreatedAt: cursor.CreatedAt, ID: cursor.ID}
+	}
+
+	return repoPage, nil
+}
+
+// searchQuery validates the raw text and page of a task search request
+func (s *taskQueryService) searchQuery(text string, page serviceInterfaces.PageRequest) (string, repoInterfaces.SearchPage, error) {
+	text, err := s.validator.ValidateSearch(text)
+	if err != nil {
+		return "", repoInterfaces.SearchPage{}, err
+	}
+
+	limit, err := s.validator.ValidateLimit(page.Limit)
+	if err != nil {
+		return "", repoInterfaces.SearchPage{}, err
+	}
+
+	repoPage := repoInterfaces.SearchPage{Limit: limit}
+	if page.Cursor != "" {
+		var cursor searchCursor
+		if err := decodeCursor(page.Cursor, &cursor); err != nil {
+			return "", repoInterfaces.SearchPage{}, err
+		}
+		if cursor.Text != text {
+			return "", repoInterfaces.SearchPage{}, fmt.Errorf("invalid cursor: it was issued for a different search")
+		}
+		if !validCursorID(cursor.ID) || math.Abs(cursor.Rank) > math.MaxFloat32 {
+			return "", repoInterfaces.SearchPage{}, fmt.Errorf("invalid cursor")
+		}
+		repoPage.After = &repoInterfaces.SearchCursor{Rank: cursor.Rank, ID: cursor.ID}
+	}
+
+	return text, repoPage, nil
+}
@@ -2,6 +2,7 @@ package TaskQueryService
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -18,27 +19,101 @@ type MockTaskQueryRepository struct {
 	mock.Mock
 }
 
-func (m *MockTaskQueryRepository) GetActiveTasks(ctx context.Context, clientName string, clientID string) ([]repoInterfaces.TaskDTO, error) {
-	args := m.Called(ctx, clientName, clientID)
+func (m *MockTaskQueryRepository) GetActiveTasks(
+	ctx context.Context,
+	clientName string,
+	clientID string,
+	filter repoInterfaces.TaskFilter,
+	page repoInterfaces.TaskPage,
+) ([]repoInterfaces.TaskDTO, error) {
+	args := m.Called(ctx, clientName, clientID, filter, page)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]repoInterfaces.TaskDTO), args.Error(1)
 }
 
-func (m *MockTaskQueryRepository) GetTaskStatusHistory(ctx context.Context, clientName string, clientID string) ([]repoInterfaces.TaskStatusDTO, error) {
-	args := m.Called(ctx, clientName, clientID)
+func (m *MockTaskQueryRepository) GetTaskStatusHistory(ctx context.Context, clientName string, clientID string, page repoInterfaces.HistoryPage) ([]repoInterfaces.TaskStatusDTO, error) {
+	args := m.Called(ctx, clientName, clientID, page)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
 	return args.Get(0).([]repoInterfaces.TaskStatusDTO), args.Error(1)
 }
 
+func (m *MockTaskQueryRepository) GetTask(ctx context.Context, clientName string, clientID string, id int) (*repoInterfaces.TaskDTO, error) {
+	args := m.Called(ctx, clientName, clientID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*repoInterfaces.TaskDTO), args.Error(1)
+}
+
+func (m *MockTaskQueryRepository) GetTaskTimeline(ctx context.Context, clientID string, taskID int) ([]repoInterfaces.TaskStatusDTO, error) {
+	args := m.Called(ctx, clientID, taskID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repoInterfaces.TaskStatusDTO), args.Error(1)
+}
+
+func (m *MockTaskQueryRepository) SearchTasks(ctx context.Context, clientName string, clientID string, text string, page repoInterfaces.SearchPage) ([]repoInterfaces.TaskSearchResult, error) {
+	args := m.Called(ctx, clientName, clientID, text, page)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repoInterfaces.TaskSearchResult), args.Error(1)
+}
+
+func (m *MockTaskQueryRepository) GetGroupReport(ctx context.Context, clientName string, clientID string, groupBy string) ([]repoInterfaces.GroupReportDTO, error) {
+	args := m.Called(ctx, clientName, clientID, groupBy)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repoInterfaces.GroupReportDTO), args.Error(1)
+}
+
+func (m *MockTaskQueryRepository) GetHireReport(ctx context.Context, clientName string, clientID string, period string) ([]repoInterfaces.HireCountDTO, error) {
+	args := m.Called(ctx, clientName, clientID, period)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repoInterfaces.HireCountDTO), args.Error(1)
+}
+
+func (m *MockTaskQueryRepository) GetStatusDurations(ctx context.Context, clientName string, clientID string) ([]repoInterfaces.StatusDurationDTO, error) {
+	args := m.Called(ctx, clientName, clientID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repoInterfaces.StatusDurationDTO), args.Error(1)
+}
+
+func (m *MockTaskQueryRepository) GetCompletionThroughput(ctx context.Context, clientName string, clientID string, weeks int) ([]repoInterfaces.WeeklyCountDTO, error) {
+	args := m.Called(ctx, clientName, clientID, weeks)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]repoInterfaces.WeeklyCountDTO), args.Error(1)
+}
+
+func (m *MockTaskQueryRepository) CountStuckTasks(ctx context.Context, clientName string, clientID string, stuckAfter time.Duration) (int, error) {
+	args := m.Called(ctx, clientName, clientID, stuckAfter)
+	return args.Int(0), args.Error(1)
+}
+
+// testStuckAfter is the stuck task threshold of the services under test
+const testStuckAfter = 48 * time.Hour
+
+// firstTaskPage is the repository page requested for a default active tasks query: one
+// task more than the default limit, ordered by ID
+var firstTaskPage = repoInterfaces.TaskPage{Limit: 51, SortBy: repoInterfaces.SortByID}
+
 func TestGetActiveTasks(t *testing.T) {
 	// Setup
 	logger := logrus.New()
 	mockRepo := new(MockTaskQueryRepository)
-	service := NewTaskQueryService(mockRepo, logger)
+	service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
 	ctx := context.Background()
 
 	validUUID := "123e4567-e89b-12d3-a456-426614174000"
@@ -47,6 +122,7 @@ func TestGetActiveTasks(t *testing.T) {
 		name       string
 		clientName string
 		clientID   string
+		filter     serviceInterfaces.TaskFilter
 		mockSetup  func()
 		verify     func(*testing.T, *serviceInterfaces.TasksResponseDTO, error)
 	}{
@@ -55,16 +131,17 @@ func TestGetActiveTasks(t *testing.T) {
 			clientName: "Test Client",
 			clientID:   validUUID,
 			mockSetup: func() {
-				mockRepo.On("GetActiveTasks", ctx, "Test Client", validUUID).Return([]repoInterfaces.TaskDTO{
+				mockRepo.On("GetActiveTasks", ctx, "Test Client", validUUID, repoInterfaces.TaskFilter{}, firstTaskPage).Return([]repoInterfaces.TaskDTO{
 					{
-						ID:         1,
-						Name:       "John Doe",
-						Email:      "john@example.com",
-						Department: "IT",
-						Position:   "Developer",
-						IsActive:   true,
-						ClientName: "Test Client",
-						ClientID:   validUUID,
+						ID:            1,
+						Name:          "John Doe",
+						Email:         "john@example.com",
+						Department:    "IT",
+						Position:      "Developer",
+						IsActive:      true,
+						ClientName:    "Test Client",
+						ClientID:      validUUID,
+						CurrentStatus: "IN_PROGRESS",
 					},
 				}, nil).Once()
 			},
@@ -74,6 +151,33 @@ func TestGetActiveTasks(t *testing.T) {
 				assert.Equal(t, 1, response.TotalCount)
 				assert.Len(t, response.Tasks, 1)
 				assert.Equal(t, "John Doe", response.Tasks[0].Name)
+				assert.Equal(t, "IN_PROGRESS", response.Tasks[0].Status)
+			},
+		},
+		{
+			name:       "Status filter is normalised",
+			clientName: "Test Client",
+			clientID:   validUUID,
+			filter:     serviceInterfaces.TaskFilter{Status: " completed "},
+			mockSetup: func() {
+				mockRepo.On("GetActiveTasks", ctx, "Test Client", validUUID, repoInterfaces.TaskFilter{Status: "COMPLETED"}, firstTaskPage).
+					Return([]repoInterfaces.TaskDTO{}, nil).Once()
+			},
+			verify: func(t *testing.T, response *serviceInterfaces.TasksResponseDTO, err error) {
+				assert.NoError(t, err)
+				assert.True(t, response.Success)
+			},
+		},
+		{
+			name:       "Unknown status filter",
+			clientName: "Test Client",
+			clientID:   validUUID,
+			filter:     serviceInterfaces.TaskFilter{Status: "DONE"},
+			mockSetup:  func() {},
+			verify: func(t *testing.T, response *serviceInterfaces.TasksResponseDTO, err error) {
+				assert.NoError(t, err)
+				assert.False(t, response.Success)
+				assert.Contains(t, response.Message, "invalid status")
 			},
 		},
 		{
@@ -103,7 +207,7 @@ func TestGetActiveTasks(t *testing.T) {
 			clientName: "Test Client",
 			clientID:   validUUID,
 			mockSetup: func() {
-				mockRepo.On("GetActiveTasks", ctx, "Test Client", validUUID).Return([]repoInterfaces.TaskDTO{}, nil).Once()
+				mockRepo.On("GetActiveTasks", ctx, "Test Client", validUUID, repoInterfaces.TaskFilter{}, firstTaskPage).Return([]repoInterfaces.TaskDTO{}, nil).Once()
 			},
 			verify: func(t *testing.T, response *serviceInterfaces.TasksResponseDTO, err error) {
 				assert.NoError(t, err)
@@ -121,7 +225,7 @@ func TestGetActiveTasks(t *testing.T) {
 			mockRepo.Calls = nil
 
 			tt.mockSetup()
-			response, err := service.GetActiveTasks(ctx, tt.clientName, tt.clientID)
+			response, err := service.GetActiveTasks(ctx, tt.clientName, tt.clientID, tt.filter, serviceInterfaces.TaskSort{}, serviceInterfaces.PageRequest{})
 			tt.verify(t, response, err)
 			mockRepo.AssertExpectations(t)
 		})
@@ -132,7 +236,7 @@ func TestGetTaskStatusHistory(t *testing.T) {
 	// Setup
 	logger := logrus.New()
 	mockRepo := new(MockTaskQueryRepository)
-	service := NewTaskQueryService(mockRepo, logger)
+	service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
 	ctx := context.Background()
 
 	validUUID := "123e4567-e89b-12d3-a456-426614174000"
@@ -150,7 +254,7 @@ func TestGetTaskStatusHistory(t *testing.T) {
 			clientName: "Test Client",
 			clientID:   validUUID,
 			mockSetup: func() {
-				mockRepo.On("GetTaskStatusHistory", ctx, "Test Client", validUUID).Return([]repoInterfaces.TaskStatusDTO{
+				mockRepo.On("GetTaskStatusHistory", ctx, "Test Client", validUUID, repoInterfaces.HistoryPage{Limit: 51}).Return([]repoInterfaces.TaskStatusDTO{
 					{
 						TaskID:            1,
 						Status:            "IN_PROGRESS",
@@ -195,7 +299,7 @@ func TestGetTaskStatusHistory(t *testing.T) {
 			clientName: "Test Client",
 			clientID:   validUUID,
 			mockSetup: func() {
-				mockRepo.On("GetTaskStatusHistory", ctx, "Test Client", validUUID).Return([]repoInterfaces.TaskStatusDTO{}, nil).Once()
+				mockRepo.On("GetTaskStatusHistory", ctx, "Test Client", validUUID, repoInterfaces.HistoryPage{Limit: 51}).Return([]repoInterfaces.TaskStatusDTO{}, nil).Once()
 			},
 			verify: func(t *testing.T, response *serviceInterfaces.StatusHistoryResponseDTO, err error) {
 				assert.NoError(t, err)
@@ -213,9 +317,398 @@ func TestGetTaskStatusHistory(t *testing.T) {
 			mockRepo.Calls = nil
 
 			tt.mockSetup()
-			response, err := service.GetTaskStatusHistory(ctx, tt.clientName, tt.clientID)
+			response, err := service.GetTaskStatusHistory(ctx, tt.clientName, tt.clientID, serviceInterfaces.PageRequest{})
 			tt.verify(t, response, err)
 			mockRepo.AssertExpectations(t)
 		})
 	}
 }
+
+func TestGetActiveTasksPagination(t *testing.T) {
+	logger := logrus.New()
+	ctx := context.Background()
+	validUUID := "123e4567-e89b-12d3-a456-426614174000"
+	hired := time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("Full page returns a cursor that continues after its last task", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+		sort := serviceInterfaces.TaskSort{Field: "salary", Order: "desc"}
+
+		mockRepo.On("GetActiveTasks", ctx, "Test Client", validUUID, repoInterfaces.TaskFilter{},
+			repoInterfaces.TaskPage{Limit: 3, SortBy: repoInterfaces.SortBySalary, Descending: true}).
+			Return([]repoInterfaces.TaskDTO{
+				{ID: 4, Salary: 90000},
+				{ID: 2, Salary: 75000.5},
+				{ID: 9, Salary: 60000},
+			}, nil).Once()
+
+		first, err := service.GetActiveTasks(ctx, "Test Client", validUUID, serviceInterfaces.TaskFilter{}, sort,
+			serviceInterfaces.PageRequest{Limit: "2"})
+		assert.NoError(t, err)
+		assert.True(t, first.Success)
+		assert.Len(t, first.Tasks, 2)
+		assert.NotEmpty(t, first.NextCursor)
+
+		mockRepo.On("GetActiveTasks", ctx, "Test Client", validUUID, repoInterfaces.TaskFilter{},
+			repoInterfaces.TaskPage{
+				Limit:      3,
+				SortBy:     repoInterfaces.SortBySalary,
+				Descending: true,
+				After:      &repoInterfaces.TaskCursor{SortValue: "75000.5", ID: 2},
+			}).
+			Return([]repoInterfaces.TaskDTO{{ID: 9, Salary: 60000}}, nil).Once()
+
+		second, err := service.GetActiveTasks(ctx, "Test Client", validUUID, serviceInterfaces.TaskFilter{}, sort,
+			serviceInterfaces.PageRequest{Limit: "2", Cursor: first.NextCursor})
+		assert.NoError(t, err)
+		assert.Len(t, second.Tasks, 1)
+		assert.Empty(t, second.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Filters are parsed into typed bounds", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+		minSalary := 50000.0
+
+		mockRepo.On("GetActiveTasks", ctx, "Test Client", validUUID, repoInterfaces.TaskFilter{
+			Department: "IT",
+			HiredFrom:  &hired,
+			MinSalary:  &minSalary,
+		}, firstTaskPage).Return([]repoInterfaces.TaskDTO{}, nil).Once()
+
+		response, err := service.GetActiveTasks(ctx, "Test Client", validUUID, serviceInterfaces.TaskFilter{
+			Department: " IT ",
+			HiredFrom:  "2020-03-01",
+			MinSalary:  "50000",
+		}, serviceInterfaces.TaskSort{}, serviceInterfaces.PageRequest{})
+		assert.NoError(t, err)
+		assert.True(t, response.Success)
+		mockRepo.AssertExpectations(t)
+	})
+
+	invalid := []struct {
+		name    string
+		filter  serviceInterfaces.TaskFilter
+		sort    serviceInterfaces.TaskSort
+		page    serviceInterfaces.PageRequest
+		message string
+	}{
+		{name: "Unknown sort field", sort: serviceInterfaces.TaskSort{Field: "email"}, message: "invalid sort"},
+		{name: "Unknown order", sort: serviceInterfaces.TaskSort{Field: "name", Order: "up"}, message: "invalid order"},
+		{name: "Limit above maximum", page: serviceInterfaces.PageRequest{Limit: "501"}, message: "invalid limit"},
+		{name: "Malformed hire date", filter: serviceInterfaces.TaskFilter{HiredTo: "01/02/2020"}, message: "invalid hired_to"},
+		{name: "Negative salary", filter: serviceInterfaces.TaskFilter{MaxSalary: "-1"}, message: "invalid max_salary"},
+		{name: "Garbled cursor", page: serviceInterfaces.PageRequest{Cursor: "not a cursor"}, message: "invalid cursor"},
+		{
+			name:    "Cursor issued for another sort",
+			sort:    serviceInterfaces.TaskSort{Field: "name"},
+			page:    serviceInterfaces.PageRequest{Cursor: encodeCursor(taskCursor{Sort: "salary", Value: "1", ID: 1})},
+			message: "different sort",
+		},
+		{
+			name:    "Cursor with a malformed sort value",
+			sort:    serviceInterfaces.TaskSort{Field: "hire_date"},
+			page:    serviceInterfaces.PageRequest{Cursor: encodeCursor(taskCursor{Sort: "hire_date", Value: "2020-13-45", ID: 1})},
+			message: "invalid cursor",
+		},
+		{
+			name:    "Cursor with a sort value of another type",
+			sort:    serviceInterfaces.TaskSort{Field: "salary"},
+			page:    serviceInterfaces.PageRequest{Cursor: encodeCursor(taskCursor{Sort: "salary", Value: "1e400", ID: 1})},
+			message: "invalid cursor",
+		},
+		{
+			name:    "Cursor with an ID out of range",
+			page:    serviceInterfaces.PageRequest{Cursor: encodeCursor(taskCursor{Sort: "id", Value: "1", ID: 1 << 40})},
+			message: "invalid cursor",
+		},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskQueryRepository)
+			service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+			response, err := service.GetActiveTasks(ctx, "Test Client", validUUID, tt.filter, tt.sort, tt.page)
+			assert.NoError(t, err)
+			assert.False(t, response.Success)
+			assert.Contains(t, response.Message, tt.message)
+			mockRepo.AssertNotCalled(t, "GetActiveTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetTask(t *testing.T) {
+	logger := logrus.New()
+	ctx := context.Background()
+	validUUID := "123e4567-e89b-12d3-a456-426614174000"
+	now := time.Now()
+
+	t.Run("Task is returned with every field and its timeline", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		mockRepo.On("GetTask", ctx, "Test Client", validUUID, 7).Return(&repoInterfaces.TaskDTO{
+			ID:            7,
+			Name:          "John Doe",
+			Address:       "123 Elm St",
+			PhoneNumber:   "555-1234",
+			Salary:        60000,
+			HireDate:      time.Date(2006, 1, 15, 0, 0, 0, 0, time.UTC),
+			IsActive:      true,
+			CurrentStatus: "IN_PROGRESS",
+		}, nil).Once()
+		mockRepo.On("GetTaskTimeline", ctx, validUUID, 7).Return([]repoInterfaces.TaskStatusDTO{
+			{TaskID: 7, Status: "PENDING", CreatedAt: now.Add(-time.Hour)},
+			{TaskID: 7, Status: "IN_PROGRESS", CreatedAt: now},
+		}, nil).Once()
+
+		response, err := service.GetTask(ctx, "Test Client", validUUID, 7)
+		assert.NoError(t, err)
+		assert.True(t, response.Success)
+		assert.Equal(t, "123 Elm St", response.Task.Address)
+		assert.Equal(t, "555-1234", response.Task.PhoneNumber)
+		assert.Equal(t, 60000.0, response.Task.Salary)
+		assert.Equal(t, "2006-01-15", response.Task.HireDate)
+		assert.Equal(t, "IN_PROGRESS", response.Task.Status)
+		assert.Len(t, response.Task.Timeline, 2)
+		assert.Equal(t, "PENDING", response.Task.Timeline[0].Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Task of another client is not found", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		mockRepo.On("GetTask", ctx, "Test Client", validUUID, 7).Return(nil, repoInterfaces.ErrTaskNotFound).Once()
+
+		response, err := service.GetTask(ctx, "Test Client", validUUID, 7)
+		assert.ErrorIs(t, err, serviceInterfaces.ErrTaskNotFound)
+		assert.False(t, response.Success)
+		assert.Nil(t, response.Task)
+		mockRepo.AssertNotCalled(t, "GetTaskTimeline", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestSearchTasks(t *testing.T) {
+	logger := logrus.New()
+	ctx := context.Background()
+	validUUID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("Results are returned by rank with a cursor for the next page", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		mockRepo.On("SearchTasks", ctx, "Test Client", validUUID, "john", repoInterfaces.SearchPage{Limit: 3}).
+			Return([]repoInterfaces.TaskSearchResult{
+				{TaskDTO: repoInterfaces.TaskDTO{ID: 4, Name: "John Doe", CurrentStatus: "PENDING"}, Rank: 1.1},
+				{TaskDTO: repoInterfaces.TaskDTO{ID: 2, Name: "Johnny Smith", CurrentStatus: "COMPLETED"}, Rank: 0.5},
+				{TaskDTO: repoInterfaces.TaskDTO{ID: 9, Name: "Ann Johnson"}, Rank: 0.25},
+			}, nil).Once()
+
+		first, err := service.SearchTasks(ctx, "Test Client", validUUID, " john ", serviceInterfaces.PageRequest{Limit: "2"})
+		assert.NoError(t, err)
+		assert.True(t, first.Success)
+		assert.Len(t, first.Results, 2)
+		assert.Equal(t, "John Doe", first.Results[0].Name)
+		assert.Equal(t, "COMPLETED", first.Results[1].Status)
+		assert.NotEmpty(t, first.NextCursor)
+
+		mockRepo.On("SearchTasks", ctx, "Test Client", validUUID, "john", repoInterfaces.SearchPage{
+			Limit: 3,
+			After: &repoInterfaces.SearchCursor{Rank: 0.5, ID: 2},
+		}).Return([]repoInterfaces.TaskSearchResult{
+			{TaskDTO: repoInterfaces.TaskDTO{ID: 9, Name: "Ann Johnson"}, Rank: 0.25},
+		}, nil).Once()
+
+		second, err := service.SearchTasks(ctx, "Test Client", validUUID, "john",
+			serviceInterfaces.PageRequest{Limit: "2", Cursor: first.NextCursor})
+		assert.NoError(t, err)
+		assert.Len(t, second.Results, 1)
+		assert.Empty(t, second.NextCursor)
+		mockRepo.AssertExpectations(t)
+	})
+
+	invalid := []struct {
+		name    string
+		text    string
+		page    serviceInterfaces.PageRequest
+		message string
+	}{
+		{name: "Empty search", text: "  ", message: "invalid q"},
+		{name: "Search too short", text: "j", message: "invalid q"},
+		{name: "Search too long", text: strings.Repeat("a", 101), message: "invalid q"},
+		{name: "Limit above maximum", text: "john", page: serviceInterfaces.PageRequest{Limit: "501"}, message: "invalid limit"},
+		{
+			name:    "Cursor issued for another search",
+			text:    "john",
+			page:    serviceInterfaces.PageRequest{Cursor: encodeCursor(searchCursor{Text: "jane", Rank: 1, ID: 1})},
+			message: "different search",
+		},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskQueryRepository)
+			service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+			response, err := service.SearchTasks(ctx, "Test Client", validUUID, tt.text, tt.page)
+			assert.NoError(t, err)
+			assert.False(t, response.Success)
+			assert.Contains(t, response.Message, tt.message)
+			mockRepo.AssertNotCalled(t, "SearchTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestGetGroupReport(t *testing.T) {
+	logger := logrus.New()
+	ctx := context.Background()
+	validUUID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("Department report is returned per group", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		mockRepo.On("GetGroupReport", ctx, "Test Client", validUUID, repoInterfaces.GroupByDepartment).
+			Return([]repoInterfaces.GroupReportDTO{
+				{Group: "IT", Headcount: 2, AverageSalary: 70000, MinSalary: 60000, MaxSalary: 80000, TotalSalary: 140000, AverageAge: 31.5},
+				{Group: "Sales", Headcount: 1, AverageSalary: 50000, MinSalary: 50000, MaxSalary: 50000, TotalSalary: 50000, AverageAge: 40},
+			}, nil).Once()
+
+		response, err := service.GetGroupReport(ctx, "Test Client", validUUID, "department")
+		assert.NoError(t, err)
+		assert.True(t, response.Success)
+		assert.Equal(t, "department", response.GroupBy)
+		assert.Equal(t, 2, response.TotalCount)
+		assert.Equal(t, 140000.0, response.Groups[0].TotalSalary)
+		assert.Equal(t, 31.5, response.Groups[0].AverageAge)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Unknown grouping is rejected", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		response, err := service.GetGroupReport(ctx, "Test Client", validUUID, "salary")
+		assert.NoError(t, err)
+		assert.False(t, response.Success)
+		assert.Contains(t, response.Message, "invalid group")
+		mockRepo.AssertNotCalled(t, "GetGroupReport", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetHireReport(t *testing.T) {
+	logger := logrus.New()
+	ctx := context.Background()
+	validUUID := "123e4567-e89b-12d3-a456-426614174000"
+	hires := []repoInterfaces.HireCountDTO{
+		{Period: time.Date(2020, 3, 1, 0, 0, 0, 0, time.UTC), Hires: 2},
+		{Period: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), Hires: 1},
+	}
+
+	tests := []struct {
+		name    string
+		period  string
+		query   string
+		periods []string
+	}{
+		{name: "Hires are counted by month by default", query: repoInterfaces.PeriodMonth, periods: []string{"2020-03", "2021-01"}},
+		{name: "Hires are counted by year", period: "YEAR", query: repoInterfaces.PeriodYear, periods: []string{"2020", "2021"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockTaskQueryRepository)
+			service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+			mockRepo.On("GetHireReport", ctx, "Test Client", validUUID, tt.query).Return(hires, nil).Once()
+
+			response, err := service.GetHireReport(ctx, "Test Client", validUUID, tt.period)
+			assert.NoError(t, err)
+			assert.True(t, response.Success)
+			assert.Equal(t, tt.query, response.Period)
+			assert.Equal(t, tt.periods, []string{response.Hires[0].Period, response.Hires[1].Period})
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("Unknown period is rejected", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		response, err := service.GetHireReport(ctx, "Test Client", validUUID, "week")
+		assert.NoError(t, err)
+		assert.False(t, response.Success)
+		assert.Contains(t, response.Message, "invalid period")
+		mockRepo.AssertNotCalled(t, "GetHireReport", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestGetStatusAnalytics(t *testing.T) {
+	logger := logrus.New()
+	ctx := context.Background()
+	validUUID := "123e4567-e89b-12d3-a456-426614174000"
+
+	t.Run("Durations, throughput and stuck tasks are reported", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		mockRepo.On("GetStatusDurations", ctx, "Test Client", validUUID).Return([]repoInterfaces.StatusDurationDTO{
+			{Status: "IN_PROGRESS", Count: 4, AverageSeconds: 7200, MedianSeconds: 3600, P90Seconds: 14400, P95Seconds: 18000},
+			{Status: "PENDING", Count: 6, AverageSeconds: 600, MedianSeconds: 300, P90Seconds: 1200, P95Seconds: 1500},
+		}, nil).Once()
+		mockRepo.On("GetCompletionThroughput", ctx, "Test Client", validUUID, 2).Return([]repoInterfaces.WeeklyCountDTO{
+			{WeekStart: time.Date(2024, 3, 4, 0, 0, 0, 0, time.UTC), Completed: 3},
+			{WeekStart: time.Date(2024, 3, 11, 0, 0, 0, 0, time.UTC), Completed: 0},
+		}, nil).Once()
+		mockRepo.On("CountStuckTasks", ctx, "Test Client", validUUID, testStuckAfter).Return(2, nil).Once()
+
+		response, err := service.GetStatusAnalytics(ctx, "Test Client", validUUID, "2")
+		assert.NoError(t, err)
+		assert.True(t, response.Success)
+		assert.Len(t, response.Durations, 2)
+		assert.Equal(t, 3600.0, response.Durations[0].MedianSeconds)
+		assert.Equal(t, "2024-03-11", response.Throughput[1].WeekStart)
+		assert.Equal(t, 0, response.Throughput[1].Completed)
+		assert.Equal(t, &serviceInterfaces.StuckTasksDTO{ThresholdHours: 48, Count: 2}, response.Stuck)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Throughput covers twelve weeks by default", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		mockRepo.On("GetStatusDurations", ctx, "Test Client", validUUID).Return([]repoInterfaces.StatusDurationDTO{}, nil).Once()
+		mockRepo.On("GetCompletionThroughput", ctx, "Test Client", validUUID, 12).Return([]repoInterfaces.WeeklyCountDTO{}, nil).Once()
+		mockRepo.On("CountStuckTasks", ctx, "Test Client", validUUID, testStuckAfter).Return(0, nil).Once()
+
+		response, err := service.GetStatusAnalytics(ctx, "Test Client", validUUID, "")
+		assert.NoError(t, err)
+		assert.True(t, response.Success)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Invalid week count is rejected", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		response, err := service.GetStatusAnalytics(ctx, "Test Client", validUUID, "105")
+		assert.NoError(t, err)
+		assert.False(t, response.Success)
+		assert.Contains(t, response.Message, "invalid weeks")
+		mockRepo.AssertNotCalled(t, "GetStatusDurations", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Repository failure is returned", func(t *testing.T) {
+		mockRepo := new(MockTaskQueryRepository)
+		service := NewTaskQueryService(mockRepo, logger, testStuckAfter)
+
+		mockRepo.On("GetStatusDurations", ctx, "Test Client", validUUID).Return([]repoInterfaces.StatusDurationDTO{}, nil).Once()
+		mockRepo.On("GetCompletionThroughput", ctx, "Test Client", validUUID, 12).Return(nil, assert.AnError).Once()
+
+		response, err := service.GetStatusAnalytics(ctx, "Test Client", validUUID, "")
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.False(t, response.Success)
+		mockRepo.AssertNotCalled(t, "CountStuckTasks", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
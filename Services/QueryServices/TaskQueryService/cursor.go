@@ -0,0 +1,59 @@
+package TaskQueryService
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// taskCursor is the decoded next_cursor of an active tasks page. It records the sort it
+// was issued for so that it cannot be replayed against a different order.
+type taskCursor struct {
+	Sort       string `json:"sort"`
+	Descending bool   `json:"desc"`
+	Value      string `json:"value"`
+	ID         int    `json:"id"`
+}
+
+// historyCursor is the decoded next_cursor of a status history page
+type historyCursor struct {
+	CreatedAt time.Time `json:"created_at"`
+	ID        int       `json:"id"`
+}
+
+// searchCursor is the decoded next_cursor of a task search page. It records the search
+// text it was issued for so that it cannot be replayed against a different search.
+type searchCursor struct {
+	Text string  `json:"text"`
+	Rank float64 `json:"rank"`
+	ID   int     `json:"id"`
+}
+
+// encodeCursor renders a cursor as an opaque URL-safe string
+func encodeCursor(cursor interface{}) string {
+	data, err := json.Marshal(cursor)
+	if err != nil {
+		// The cursor types only hold strings, numbers and times
+		panic(fmt.Sprintf("failed to encode cursor: %v", err))
+	}
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor reads a cursor produced by encodeCursor
+func decodeCursor(value string, cursor interface{}) error {
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return fmt.Errorf("invalid cursor")
+	}
+	if err := json.Unmarshal(data, cursor); err != nil {
+		return fmt.Errorf("invalid cursor")
+	}
+	return nil
+}
+
+// validCursorID reports whether id fits the integer ID columns a cursor is compared with
+func validCursorID(id int) bool {
+	return id > 0 && id <= math.MaxInt32
+}
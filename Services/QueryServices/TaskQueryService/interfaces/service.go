@@ -2,16 +2,77 @@ package interfaces
 
 import (
 	"context"
+	"errors"
 	"time"
 )
 
 // TaskQueryService defines the interface for querying tasks
 type TaskQueryService interface {
-	// GetActiveTasks retrieves active tasks for a specific client
-	GetActiveTasks(ctx context.Context, clientName string, clientID string) (*TasksResponseDTO, error)
+	// GetActiveTasks retrieves one page of active tasks for a specific client with their
+	// current status
+	GetActiveTasks(
+		ctx context.Context,
+		clientName string,
+		clientID string,
+		filter TaskFilter,
+		sort TaskSort,
+		page PageRequest,
+	) (*TasksResponseDTO, error)
 
-	// GetTaskStatusHistory retrieves status history for a specific client
-	GetTaskStatusHistory(ctx context.Context, clientName string, clientID string) (*StatusHistoryResponseDTO, error)
+	// GetTaskStatusHistory retrieves one page of status history for a specific client
+	GetTaskStatusHistory(ctx context.Context, clientName string, clientID string, page PageRequest) (*StatusHistoryResponseDTO, error)
+
+	// GetTask retrieves one task of a specific client with its status timeline, or
+	// ErrTaskNotFound
+	GetTask(ctx context.Context, clientName string, clientID string, id int) (*TaskResponseDTO, error)
+
+	// SearchTasks retrieves one page of a client's active tasks matching a search text,
+	// most relevant first
+	SearchTasks(ctx context.Context, clientName string, clientID string, text string, page PageRequest) (*TaskSearchResponseDTO, error)
+
+	// GetGroupReport aggregates a client's active tasks per department or position
+	GetGroupReport(ctx context.Context, clientName string, clientID string, groupBy string) (*GroupReportResponseDTO, error)
+
+	// GetHireReport counts a client's active tasks by hire month (default) or year
+	GetHireReport(ctx context.Context, clientName string, clientID string, period string) (*HireReportResponseDTO, error)
+
+	// GetStatusAnalytics reports how long a client's tasks stay in each status, how many
+	// were completed in each of the last weeks (default 12) and how many are stuck
+	// IN_PROGRESS
+	GetStatusAnalytics(ctx context.Context, clientName string, clientID string, weeks string) (*StatusAnalyticsResponseDTO, error)
+}
+
+// ErrTaskNotFound is returned when a task does not exist for the calling client
+var ErrTaskNotFound = errors.New("task not found")
+
+// TaskFilter narrows the active tasks returned for a client. Fields hold the raw query
+// values and are validated by the service; empty fields do not filter.
+type TaskFilter struct {
+	// Status keeps only tasks currently in this status, in any letter case
+	Status string
+	// Department and Position match ignoring case
+	Department string
+	Position   string
+	// HiredFrom and HiredTo are inclusive YYYY-MM-DD bounds on the hire date
+	HiredFrom string
+	HiredTo   string
+	// MinSalary and MaxSalary are inclusive bounds on the salary
+	MinSalary string
+	MaxSalary string
+}
+
+// TaskSort orders the active tasks: Field is name, hire_date, salary or created_at
+// (default: task ID) and Order is asc (default) or desc
+type TaskSort struct {
+	Field string
+	Order string
+}
+
+// PageRequest selects a page: Limit defaults to 50 and may be up to 500, and Cursor is
+// the next_cursor of the previous page
+type PageRequest struct {
+	Limit  string
+	Cursor string
 }
 
 // TasksResponseDTO represents the response for active tasks query
@@ -20,6 +81,8 @@ type TasksResponseDTO struct {
 	Message    string          `json:"message"`
 	Tasks      []TaskDetailDTO `json:"tasks,omitempty"`
 	TotalCount int             `json:"total_count"`
+	// NextCursor fetches the following page; it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // StatusHistoryResponseDTO represents the response for status history query
@@ -28,6 +91,8 @@ type StatusHistoryResponseDTO struct {
 	Message    string            `json:"message"`
 	History    []StatusDetailDTO `json:"history,omitempty"`
 	TotalCount int               `json:"total_count"`
+	// NextCursor fetches the following page; it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // TaskDetailDTO represents detailed task information
@@ -40,6 +105,131 @@ type TaskDetailDTO struct {
 	IsActive   bool   `json:"is_active"`
 	ClientName string `json:"client_name"`
 	ClientID   string `json:"client_id"`
+	// Status is the latest status of the task; tasks without history are PENDING
+	Status            string     `json:"status"`
+	StatusDescription string     `json:"status_description,omitempty"`
+	StatusUpdatedAt   *time.Time `json:"status_updated_at,omitempty"`
+}
+
+// TaskResponseDTO represents the response for a single task query
+type TaskResponseDTO struct {
+	Success bool     `json:"success"`
+	Message string   `json:"message"`
+	Task    *TaskDTO `json:"task,omitempty"`
+}
+
+// TaskDTO represents every field of a task with its status timeline, oldest first
+type TaskDTO struct {
+	ID                int               `json:"id"`
+	Name              string            `json:"name"`
+	Email             string            `json:"email"`
+	Age               int               `json:"age"`
+	Address           string            `json:"address"`
+	PhoneNumber       string            `json:"phone_number"`
+	Department        string            `json:"department"`
+	Position          string            `json:"position"`
+	Salary            float64           `json:"salary"`
+	HireDate          string            `json:"hire_date"`
+	IsActive          bool              `json:"is_active"`
+	ClientName        string            `json:"client_name"`
+	ClientID          string            `json:"client_id"`
+	CreatedAt         time.Time         `json:"created_at"`
+	UpdatedAt         time.Time         `json:"updated_at"`
+	Status            string            `json:"status"`
+	StatusDescription string            `json:"status_description,omitempty"`
+	StatusUpdatedAt   *time.Time        `json:"status_updated_at,omitempty"`
+	Timeline          []StatusDetailDTO `json:"timeline"`
+}
+
+// TaskSearchResponseDTO represents the response for a task search
+type TaskSearchResponseDTO struct {
+	Success    bool                  `json:"success"`
+	Message    string                `json:"message"`
+	Results    []TaskSearchResultDTO `json:"results,omitempty"`
+	TotalCount int                   `json:"total_count"`
+	// NextCursor fetches the following page; it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// TaskSearchResultDTO represents a task matching a search; higher ranks match better
+type TaskSearchResultDTO struct {
+	ID         int     `json:"id"`
+	Name       string  `json:"name"`
+	Email      string  `json:"email"`
+	Address    string  `json:"address"`
+	Department string  `json:"department"`
+	Position   string  `json:"position"`
+	Status     string  `json:"status"`
+	Rank       float64 `json:"rank"`
+}
+
+// GroupReportResponseDTO represents the response for a department or position report
+type GroupReportResponseDTO struct {
+	Success    bool             `json:"success"`
+	Message    string           `json:"message"`
+	GroupBy    string           `json:"group_by,omitempty"`
+	Groups     []GroupReportDTO `json:"groups,omitempty"`
+	TotalCount int              `json:"total_count"`
+}
+
+// GroupReportDTO represents the headcount, payroll and average age of one department or
+// position
+type GroupReportDTO struct {
+	Group         string  `json:"group"`
+	Headcount     int     `json:"headcount"`
+	AverageSalary float64 `json:"average_salary"`
+	MinSalary     float64 `json:"min_salary"`
+	MaxSalary     float64 `json:"max_salary"`
+	TotalSalary   float64 `json:"total_salary"`
+	AverageAge    float64 `json:"average_age"`
+}
+
+// HireReportResponseDTO represents the response for a hire count report
+type HireReportResponseDTO struct {
+	Success    bool           `json:"success"`
+	Message    string         `json:"message"`
+	Period     string         `json:"period,omitempty"`
+	Hires      []HireCountDTO `json:"hires,omitempty"`
+	TotalCount int            `json:"total_count"`
+}
+
+// HireCountDTO represents the number of hires in a month (YYYY-MM) or year (YYYY)
+type HireCountDTO struct {
+	Period string `json:"period"`
+	Hires  int    `json:"hires"`
+}
+
+// StatusAnalyticsResponseDTO represents the response for the status analytics query
+type StatusAnalyticsResponseDTO struct {
+	Success    bool                  `json:"success"`
+	Message    string                `json:"message"`
+	Durations  []StatusDurationDTO   `json:"durations,omitempty"`
+	Throughput []WeeklyThroughputDTO `json:"throughput,omitempty"`
+	Stuck      *StuckTasksDTO        `json:"stuck,omitempty"`
+}
+
+// StatusDurationDTO represents the time tasks spent in a status before their next status
+// change, in seconds
+type StatusDurationDTO struct {
+	Status         string  `json:"status"`
+	Count          int     `json:"count"`
+	AverageSeconds float64 `json:"average_seconds"`
+	MedianSeconds  float64 `json:"median_seconds"`
+	P90Seconds     float64 `json:"p90_seconds"`
+	P95Seconds     float64 `json:"p95_seconds"`
+}
+
+// WeeklyThroughputDTO represents the tasks completed in the week starting on WeekStart
+// (YYYY-MM-DD, a Monday)
+type WeeklyThroughputDTO struct {
+	WeekStart string `json:"week_start"`
+	Completed int    `json:"completed"`
+}
+
+// StuckTasksDTO represents the active tasks IN_PROGRESS for longer than ThresholdHours
+type StuckTasksDTO struct {
+	ThresholdHours float64 `json:"threshold_hours"`
+	Count          int     `json:"count"`
 }
 
 // StatusDetailDTO represents detailed status information
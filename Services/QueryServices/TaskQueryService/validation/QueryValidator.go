@@ -2,7 +2,10 @@ package validation
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
+	"unicode/utf8"
 	"github.com/google/uuid"
 )
 
@@ -27,4 +30,156 @@ func (v *QueryValidator) ValidateClientParams(clientName, clientID string) error
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+// taskStatuses are the statuses a task can be in
+var taskStatuses = []string{"PENDING", "IN_PROGRESS", "COMPLETED", "CANCELLED"}
+
+// ValidateStatus normalises a status filter; an empty filter is allowed
+func (v *QueryValidator) ValidateStatus(status string) (string, error) {
+	status = strings.ToUpper(strings.TrimSpace(status))
+	if status == "" {
+		return "", nil
+	}
+
+	for _, valid := range taskStatuses {
+		if status == valid {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("invalid status: must be one of %s", strings.Join(taskStatuses, ", "))
+}
+
+// Page size bounds for list queries
+const (
+	DefaultPageLimit = 50
+	MaxPageLimit     = 500
+)
+
+// sortFields are the fields active tasks can be sorted by
+var sortFields = []string{"name", "hire_date", "salary", "created_at"}
+
+// ValidateLimit parses a page size; an empty limit means DefaultPageLimit
+func (v *QueryValidator) ValidateLimit(value string) (int, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultPageLimit, nil
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || limit < 1 || limit > MaxPageLimit {
+		return 0, fmt.Errorf("invalid limit: must be a number between 1 and %d", MaxPageLimit)
+	}
+	return limit, nil
+}
+
+// ValidateSort checks a sort field and order; an empty field sorts by task ID and an
+// empty order is ascending
+func (v *QueryValidator) ValidateSort(field, order string) (string, bool, error) {
+	field = strings.ToLower(strings.TrimSpace(field))
+	if field != "" {
+		valid := false
+		for _, sortField := range sortFields {
+			valid = valid || field == sortField
+		}
+		if !valid {
+			return "", false, fmt.Errorf("invalid sort: must be one of %s", strings.Join(sortFields, ", "))
+		}
+	}
+
+	switch strings.ToLower(strings.TrimSpace(order)) {
+	case "", "asc":
+		return field, false, nil
+	case "desc":
+		return field, true, nil
+	default:
+		return "", false, fmt.Errorf("invalid order: must be asc or desc")
+	}
+}
+
+// ValidateDate parses an optional YYYY-MM-DD query value
+func (v *QueryValidator) ValidateDate(name, value string) (*time.Time, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	date, err := time.Parse("2006-01-02", strings.TrimSpace(value))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: must be formatted YYYY-MM-DD", name)
+	}
+	return &date, nil
+}
+
+// ValidateAmount parses an optional non-negative number query value
+func (v *QueryValidator) ValidateAmount(name, value string) (*float64, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, nil
+	}
+
+	amount, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || amount < 0 {
+		return nil, fmt.Errorf("invalid %s: must be a non-negative number", name)
+	}
+	return &amount, nil
+}
+
+
+// Length bounds of a search text, in characters
+const (
+	MinSearchLength = 2
+	MaxSearchLength = 100
+)
+
+// ValidateSearch trims a search text and checks its length
+func (v *QueryValidator) ValidateSearch(text string) (string, error) {
+	text = strings.TrimSpace(text)
+	length := utf8.RuneCountInString(text)
+	if length < MinSearchLength || length > MaxSearchLength {
+		return "", fmt.Errorf("invalid q: must be between %d and %d characters", MinSearchLength, MaxSearchLength)
+	}
+	return text, nil
+}
+
+// reportGroups are the fields tasks can be aggregated by
+var reportGroups = []string{"department", "position"}
+
+// ValidateGroupBy checks the field a report is grouped by
+func (v *QueryValidator) ValidateGroupBy(groupBy string) (string, error) {
+	groupBy = strings.ToLower(strings.TrimSpace(groupBy))
+	for _, group := range reportGroups {
+		if groupBy == group {
+			return groupBy, nil
+		}
+	}
+	return "", fmt.Errorf("invalid group: must be one of %s", strings.Join(reportGroups, ", "))
+}
+
+// ValidatePeriod checks the period hires are counted by; an empty period means month
+func (v *QueryValidator) ValidatePeriod(period string) (string, error) {
+	switch period = strings.ToLower(strings.TrimSpace(period)); period {
+	case "":
+		return "month", nil
+	case "month", "year":
+		return period, nil
+	default:
+		return "", fmt.Errorf("invalid period: must be month or year")
+	}
+}
+
+// Bounds of the number of weeks of completion throughput
+const (
+	DefaultThroughputWeeks = 12
+	MaxThroughputWeeks     = 104
+)
+
+// ValidateWeeks parses a number of weeks; an empty value means DefaultThroughputWeeks
+func (v *QueryValidator) ValidateWeeks(value string) (int, error) {
+	if strings.TrimSpace(value) == "" {
+		return DefaultThroughputWeeks, nil
+	}
+
+	weeks, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || weeks < 1 || weeks > MaxThroughputWeeks {
+		return 0, fmt.Errorf("invalid weeks: must be a number between 1 and %d", MaxThroughputWeeks)
+	}
+	return weeks, nil
+}
@@ -22,9 +22,18 @@ import (
 	"taskmanager/RequestControllers/httpSetup/config"
 	"taskmanager/RequestControllers/httpSetup/jwt"
 	"taskmanager/RequestControllers/httpSetup/logger"
+	"taskmanager/RequestControllers/httpSetup/middleware"
+	"taskmanager/Services/CommandServices/ClientService"
+	"taskmanager/Services/CommandServices/ImportJobService"
+	jobServiceInterfaces "taskmanager/Services/CommandServices/ImportJobService/interfaces"
 	"taskmanager/Services/CommandServices/ImportTaskService"
 	commandServiceInterfaces "taskmanager/Services/CommandServices/ImportTaskService/interfaces"
+	"taskmanager/Services/CommandServices/ImportTaskService/schemas"
 	"taskmanager/Services/CommandServices/ImportTaskService/validation"
+	"taskmanager/Services/CommandServices/TaskCommandService"
+	taskServiceInterfaces "taskmanager/Services/CommandServices/TaskCommandService/interfaces"
+	"taskmanager/Services/CommandServices/TokenService"
+	tokenServiceInterfaces "taskmanager/Services/CommandServices/TokenService/interfaces"
 	"taskmanager/Services/QueryServices/TaskQueryService"
 	queryServiceInterfaces "taskmanager/Services/QueryServices/TaskQueryService/interfaces"
 
@@ -39,6 +48,8 @@ const (
 	shutdownTimeout = 5 * time.Second
 	readTimeout     = 10 * time.Second
 	writeTimeout    = 30 * time.Second
+	// tokenPurgeInterval is how often expired refresh tokens and revocations are deleted
+	tokenPurgeInterval = time.Hour
 )
 
 func main() {
@@ -63,14 +74,33 @@ func main() {
 }
 
 type appDependencies struct {
-	router *gin.Engine
+	router     *gin.Engine
+	jobService jobServiceInterfaces.ImportJobService
 }
 
 func initializeApp(cfg *config.Config, logger *logrus.Logger) (*appDependencies, error) {
 	logger.Info("Initializing application dependencies")
 
 	// Initialize JWT Manager
-	jwtManager := jwt.NewJWTManager(cfg.JWT.SecretKey, cfg.JWT.ExpiryHours)
+	var devClient *jwt.ClientClaims
+	if cfg.JWT.Mode == config.AuthModeDev {
+		logger.WithField("client_name", cfg.JWT.DevClient.ClientName).
+			Warn("Authentication is in dev mode: tokens are not validated")
+		devClient = &jwt.ClientClaims{
+			ClientName: cfg.JWT.DevClient.ClientName,
+			ClientID:   cfg.JWT.DevClient.ClientID,
+			Scopes:     jwt.AllScopes,
+		}
+	}
+	keys, err := signingKeys(cfg.JWT)
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT keys: %w", err)
+	}
+	jwtManager := jwt.NewJWTManager(
+		keys,
+		time.Duration(cfg.JWT.AccessTokenMinutes)*time.Minute,
+		devClient,
+	)
 
 	// Initialize repositories
 	commandRepo, queryRepo, err := initializeRepositories(cfg, logger)
@@ -78,27 +108,96 @@ func initializeApp(cfg *config.Config, logger *logrus.Logger) (*appDependencies,
 		return nil, err
 	}
 
+	jobRepo, err := CommandRepository.NewImportJobRepository(&cfg.Database, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize import job repository: %w", err)
+	}
+
+	ledgerRepo, err := CommandRepository.NewImportLedgerRepository(&cfg.Database, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize import ledger repository: %w", err)
+	}
+
 	// Initialize services
-	commandService, queryService, err := initializeServices(cfg, logger, commandRepo, queryRepo)
+	commandService, queryService, err := initializeServices(cfg, logger, commandRepo, ledgerRepo, queryRepo)
 	if err != nil {
 		return nil, err
 	}
 
+	// Initialize background import jobs
+	jobService := ImportJobService.NewImportJobService(
+		commandService,
+		jobRepo,
+		logger,
+		cfg.Import.Workers,
+		cfg.Import.QueueSize,
+	)
+	jobService.Start()
+
+	// Initialize single task commands
+	taskService := TaskCommandService.NewTaskCommandService(
+		commandRepo,
+		validation.NewDataValidator(logger),
+		logger,
+	)
+
+	// Initialize client credentials
+	clientRepo, err := CommandRepository.NewClientRepository(&cfg.Database, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize client repository: %w", err)
+	}
+	clientService := ClientService.NewClientService(clientRepo, logger)
+
+	// Initialize refresh tokens and revocation
+	tokenRepo, err := CommandRepository.NewTokenRepository(&cfg.Database, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize token repository: %w", err)
+	}
+	tokenService := TokenService.NewTokenService(
+		tokenRepo,
+		clientRepo,
+		jwtManager,
+		time.Duration(cfg.JWT.RefreshTokenHours)*time.Hour,
+		logger,
+	)
+	go purgeExpiredTokens(tokenService, logger)
+
 	// Initialize auth controller
-	authController := AuthRequest.NewAuthController(jwtManager, logger)
+	authController := AuthRequest.NewAuthController(
+		jwtManager,
+		clientService,
+		tokenService,
+		middleware.JWTAuthMiddleware(jwtManager, tokenService),
+		cfg.JWT.AdminKey,
+		logger,
+	)
 
 	// Initialize controllers and router
-	router, err := initializeControllers(cfg, logger, commandService, queryService, authController, jwtManager)
+	router, err := initializeControllers(cfg, logger, commandService, jobService, taskService, queryService, authController, jwtManager, tokenService)
 	if err != nil {
 		return nil, err
 	}
 
 	logger.Info("Application dependencies initialized successfully")
 	return &appDependencies{
-		router: router,
+		router:     router,
+		jobService: jobService,
 	}, nil
 }
 
+// purgeExpiredTokens deletes expired tokens now and then every tokenPurgeInterval
+func purgeExpiredTokens(tokenService tokenServiceInterfaces.TokenService, logger *logrus.Logger) {
+	ticker := time.NewTicker(tokenPurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := tokenService.PurgeExpired(context.Background()); err != nil {
+			logger.WithError(err).Error("Failed to purge expired tokens")
+		}
+		<-ticker.C
+	}
+}
+
 func initializeRepositories(cfg *config.Config, logger *logrus.Logger) (
 	cmdRepo cmdRepoInterfaces.TaskCommandRepository,
 	queryRepo queryRepoInterfaces.TaskQueryRepository,
@@ -123,6 +222,7 @@ func initializeServices(
 	cfg *config.Config,
 	logger *logrus.Logger,
 	commandRepo cmdRepoInterfaces.TaskCommandRepository,
+	ledgerRepo cmdRepoInterfaces.ImportLedgerRepository,
 	queryRepo queryRepoInterfaces.TaskQueryRepository,
 ) (
 	commandService commandServiceInterfaces.ImportService,
@@ -134,34 +234,102 @@ func initializeServices(
 	// Initialize validator
 	dataValidator := validation.NewDataValidator(logger)
 
+	profiles, err := mappingProfiles(cfg.Import)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid import profiles: %w", err)
+	}
+
 	// Initialize services
 	commandService = ImportTaskService.NewImportService(
 		commandRepo,
+		ledgerRepo,
 		dataValidator,
 		logger,
 		cfg.Import.Directory,
+		profiles,
+		cfg.Import.BatchSize,
 	)
 
 	queryService = TaskQueryService.NewTaskQueryService(
 		queryRepo,
 		logger,
+		time.Duration(cfg.Analytics.StuckAfterHours)*time.Hour,
 	)
 
 	return commandService, queryService, nil
 }
 
+// signingKeys loads the JWT keys defined in the JWT config. Dev mode may run without keys,
+// in which case nil is returned.
+func signingKeys(cfg config.JWTConfig) (*jwt.KeySet, error) {
+	if len(cfg.Keys) == 0 && cfg.Mode == config.AuthModeDev {
+		return nil, nil
+	}
+
+	keys := make([]*jwt.Key, 0, len(cfg.Keys))
+	for _, keyCfg := range cfg.Keys {
+		key, err := jwt.LoadKey(keyCfg.KeyID, keyCfg.File)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return jwt.NewKeySet(cfg.SigningKeyID, keys...)
+}
+
+// mappingProfiles builds the CSV column mapping profiles defined in the import config
+func mappingProfiles(cfg config.ImportConfig) (schemas.MappingProfiles, error) {
+	profiles := schemas.MappingProfiles{
+		Default:  cfg.DefaultProfile,
+		Profiles: make(map[string]schemas.MappingProfile, len(cfg.Profiles)),
+	}
+
+	for name, profileCfg := range cfg.Profiles {
+		overrides := make(map[string]schemas.ColumnMapping, len(profileCfg.Columns))
+		for key, column := range profileCfg.Columns {
+			overrides[key] = schemas.ColumnMapping{
+				Aliases:  column.Aliases,
+				Optional: column.Optional,
+				Default:  column.Default,
+			}
+		}
+
+		profile, err := schemas.NewMappingProfile(name, overrides)
+		if err != nil {
+			return schemas.MappingProfiles{}, err
+		}
+		profiles.Profiles[name] = profile
+	}
+
+	if _, ok := profiles.Lookup(""); !ok {
+		return schemas.MappingProfiles{}, fmt.Errorf("default profile %q is not defined", cfg.DefaultProfile)
+	}
+
+	return profiles, nil
+}
+
 func initializeControllers(
 	cfg *config.Config,
 	logger *logrus.Logger,
 	commandService commandServiceInterfaces.ImportService,
+	jobService jobServiceInterfaces.ImportJobService,
+	taskService taskServiceInterfaces.TaskCommandService,
 	queryService queryServiceInterfaces.TaskQueryService,
 	authController authInterfaces.AuthController,
 	jwtManager *jwt.JWTManager,
+	tokenService tokenServiceInterfaces.TokenService,
 ) (*gin.Engine, error) {
 	logger.Info("Initializing controllers")
 
 	// Initialize controllers
-	commandController := CommandRequest.NewCommandApiController(commandService, logger)
+	commandController := CommandRequest.NewCommandApiController(
+		commandService,
+		jobService,
+		taskService,
+		int64(cfg.Import.MaxUploadMB)<<20,
+		logger,
+	)
 	queryController := QueryRequest.NewQueryApiController(queryService, logger)
 
 	// Setup HTTP router
@@ -170,6 +338,7 @@ func initializeControllers(
 		QueryController:   queryController,
 		AuthController:    authController,
 		JWTManager:        jwtManager,
+		Revocations:       tokenService,
 		Logger:            logger,
 	}
 	router := httpSetup.SetupRouter(routerConfig)
@@ -213,5 +382,10 @@ func startServerWithGracefulShutdown(app *appDependencies, cfg *config.Config, l
 		logger.WithError(err).Fatal("Server forced to shutdown")
 	}
 
+	// Stop background import jobs
+	if err := app.jobService.Stop(ctx); err != nil {
+		logger.WithError(err).Error("Import jobs did not stop cleanly")
+	}
+
 	logger.Info("Server exited gracefully")
 }